@@ -0,0 +1,60 @@
+// Точка запуска HTTP JSON API
+//
+//	api --config=./config/local.yaml
+package main
+
+import (
+	"context"
+	"dinner/internal/app"
+	"dinner/internal/config"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	// База часовых поясов для /tz, если на сервере ее нет
+	_ "time/tzdata"
+)
+
+func main() {
+	// Получаем конфигурацию
+	cfg := config.MustLoadConfig()
+
+	// Создаем логгер
+	logger := mustSetupLogger(cfg.Env)
+	if len(cfg.APIKeys) == 0 {
+		logger.Warn("api_keys is empty, all requests will be rejected")
+	}
+
+	// Запускаем API в горутине
+	api := app.NewAPI(logger, cfg)
+	go api.Run()
+
+	// Ожидаем от системы команды на остановку
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+	sign := <-stop
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := api.Stop(ctx); err != nil {
+		logger.Error("rest api stop error", slog.Any("error", err))
+	}
+	logger.Warn("api stopped", slog.String("signal", sign.String()))
+}
+
+// mustSetupLogger настраивает логгер
+func mustSetupLogger(env string) *slog.Logger {
+	var logger *slog.Logger
+	switch env {
+	case config.EnvLocal:
+		logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	case config.EnvDev:
+		logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	case config.EnvProd:
+		logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	default:
+		panic("logger not configured")
+	}
+	return logger
+}
@@ -0,0 +1,92 @@
+// Точка входа для импорта и экспорта каталога блюд
+//
+//	catalog export --storage-path=./storages/dinner.db --format=csv > foods.csv
+//	catalog import --storage-path=./storages/dinner.db --file=foods.csv --dry-run
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	catalogservice "dinner/internal/services/catalog"
+	storagesqlite "dinner/internal/storages/sqlite"
+
+	// Драйвер SQLite 3
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		panic("command is required: import or export")
+	}
+	command := os.Args[1]
+
+	var storagePath, filePath, format string
+	var dryRun bool
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	// Путь до файла БД
+	flags.StringVar(&storagePath, "storage-path", "", "path to storage")
+	// Путь до файла каталога, по умолчанию stdin/stdout
+	flags.StringVar(&filePath, "file", "", "path to catalog file")
+	// Формат файла, по умолчанию определяется по расширению
+	flags.StringVar(&format, "format", "", "catalog format: csv, json or yaml")
+	// Только показать изменения без сохранения
+	flags.BoolVar(&dryRun, "dry-run", false, "show changes without saving")
+	flags.Parse(os.Args[2:])
+
+	// Валидация параметров
+	if storagePath == "" {
+		panic("storage-path is required")
+	}
+	if format == "" {
+		format = filePath
+	}
+	catalogFormat, err := catalogservice.ParseFormat(format)
+	if err != nil {
+		panic(err)
+	}
+
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	storage, err := storagesqlite.New(log, storagePath)
+	if err != nil {
+		panic(err)
+	}
+	catalog := catalogservice.New(log, storage)
+
+	switch command {
+	case "export":
+		var w io.Writer = os.Stdout
+		if filePath != "" {
+			file, err := os.Create(filePath)
+			if err != nil {
+				panic(err)
+			}
+			defer file.Close()
+			w = file
+		}
+		if err := catalog.Export(catalogFormat, w); err != nil {
+			panic(err)
+		}
+	case "import":
+		var r io.Reader = os.Stdin
+		if filePath != "" {
+			file, err := os.Open(filePath)
+			if err != nil {
+				panic(err)
+			}
+			defer file.Close()
+			r = file
+		}
+		diff, err := catalog.Import(catalogFormat, r, dryRun)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(catalogservice.DiffText(diff))
+	default:
+		panic("unknown command: " + command)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"dinner/internal/domain/models"
+	dinnerservice "dinner/internal/services/dinner"
+)
+
+// filter отдает сервису только блюда выбранной категории
+// и без исключенных названий, тегов и ингредиентов
+type filter struct {
+	provider dinnerservice.FoodProvider
+	category models.FootCategory
+	exclude  []string
+}
+
+// newFilter разбирает флаги --category и --exclude
+func newFilter(provider dinnerservice.FoodProvider, categories []models.Category, category string, exclude string) (*filter, error) {
+	f := &filter{provider: provider}
+	if category = strings.TrimSpace(category); category != "" {
+		found := false
+		for _, c := range categories {
+			if strings.EqualFold(c.Name, category) || strconv.Itoa(int(c.ID)) == category {
+				f.category = c.ID
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown category %q", category)
+		}
+	}
+	for _, item := range strings.Split(exclude, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			f.exclude = append(f.exclude, item)
+		}
+	}
+	return f, nil
+}
+
+// GetFoods реализует dinnerservice.FoodProvider
+func (f *filter) GetFoods() ([]models.Food, error) {
+	foods, err := f.provider.GetFoods()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(foods, func(food models.Food) bool {
+		if f.category != 0 && food.Category != f.category {
+			return true
+		}
+		return slices.ContainsFunc(f.exclude, food.Mentions)
+	}), nil
+}
@@ -0,0 +1,279 @@
+// Консольный клиент: подбирает ужин прямо по файлу БД, без Telegram.
+// Удобен и для проверки данных каталога.
+//
+//	dinner pick  --storage-path=./storages/dinner.db --category=суп --exclude=грибы
+//	dinner plan  --storage-path=./storages/dinner.db --days=7 --seed=42 --date=2025-07-14 --json
+//	dinner pick  --storage-path=./storages/dinner.db --user=123 --mode=fresh
+//	dinner foods --storage-path=./storages/dinner.db --category=мясо
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"time"
+
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/formatter"
+	"dinner/internal/lib/i18n"
+	dinnerservice "dinner/internal/services/dinner"
+	storagesqlite "dinner/internal/storages/sqlite"
+
+	// Драйвер SQLite 3
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		panic("command is required: pick, plan or foods")
+	}
+	command := os.Args[1]
+
+	var storagePath, category, exclude, lang string
+	var userId int64
+	var seed uint64
+	var days int
+	var asJSON, light, full bool
+	var kcal, date, mode string
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	// Путь до файла БД
+	flags.StringVar(&storagePath, "storage-path", "", "path to storage")
+	// Категория блюд (название или номер)
+	flags.StringVar(&category, "category", "", "use only foods of category (name or id)")
+	// Исключаемые блюда, теги и ингредиенты через запятую
+	flags.StringVar(&exclude, "exclude", "", "comma separated food names, tags or ingredients to skip")
+	// Пользователь, от имени которого сохраняется история и проверяется лимит
+	flags.Int64Var(&userId, "user", 0, "telegram user id; 0 - do not check limit and save history")
+	// Зерно генератора, одинаковое зерно дает одинаковый результат
+	flags.Uint64Var(&seed, "seed", 0, "random seed to reproduce output; 0 - random")
+	// Количество дней в плане
+	flags.IntVar(&days, "days", 7, "number of days for plan")
+	// Язык вывода
+	flags.StringVar(&lang, "lang", string(i18n.Default), "output language")
+	// Легкий ужин и диапазон калорий
+	flags.BoolVar(&light, "light", false, "pick a light dinner")
+	// Ужин из нескольких подач
+	flags.BoolVar(&full, "full", false, "pick a starter, soup and main course")
+	flags.StringVar(&kcal, "kcal", "", "dinner kcal range, e.g. 400-700")
+	// Дата ужина для учета сезона и дня недели
+	// Без --date с заданным --seed календарь не учитывается, чтобы зерно повторяло результат в любой день
+	flags.StringVar(&date, "date", "", "dinner date YYYY-MM-DD for seasons and weekdays; empty - today (ignore with --seed), off - ignore")
+	// Стратегия выбора, оценки и история берутся у пользователя --user
+	flags.StringVar(&mode, "mode", "", "dinner mode: "+strings.Join(dinnerservice.StrategyNames(), ", "))
+	// Вывод в JSON
+	flags.BoolVar(&asJSON, "json", false, "print JSON")
+	flags.Parse(os.Args[2:])
+
+	// Валидация параметров
+	if storagePath == "" {
+		panic("storage-path is required")
+	}
+	outLang, ok := i18n.Parse(lang)
+	if !ok {
+		panic("unknown lang: " + lang)
+	}
+	kcalTarget, err := dinnerservice.ParseKcalRange(kcal)
+	if err != nil {
+		panic(err)
+	}
+	if _, ok := dinnerservice.StrategyByName(mode); !ok {
+		panic("unknown mode: " + mode)
+	}
+	dinnerDate, err := parseDate(date, seed != 0)
+	if err != nil {
+		panic(err)
+	}
+
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	storage, err := storagesqlite.New(log, storagePath)
+	if err != nil {
+		panic(err)
+	}
+	categories, err := storage.GetCategories()
+	if err != nil {
+		panic(err)
+	}
+	foods, err := newFilter(storage, categories, category, exclude)
+	if err != nil {
+		panic(err)
+	}
+	var history dinnerservice.HistoryProvider = offlineHistory{}
+	if userId != 0 {
+		history = storage
+	}
+	// Зерно и дату печатаем в stderr, чтобы результат можно было повторить
+	if seed == 0 {
+		seed = rand.Uint64N(math.MaxUint64) + 1
+	}
+	fmt.Fprintf(os.Stderr, "seed: %d date: %s\n", seed, formatDate(dinnerDate))
+	dinner := dinnerservice.New(log, foods, history, storage, storage, dinnerservice.NewSource(seed))
+	out := output{w: os.Stdout, lang: outLang, categories: categories, json: asJSON}
+
+	switch command {
+	case "pick":
+		res, err := dinner.GetDinner(userId, dinnerservice.Options{Kcal: kcalTarget, Light: light, Full: full, Date: dinnerDate, Mode: mode})
+		if err != nil {
+			panic(err)
+		}
+		if err := out.dinner(res); err != nil {
+			panic(err)
+		}
+	case "plan":
+		plan, err := dinner.GetPlan(userId, days, dinnerservice.Options{Date: dinnerDate, Mode: mode})
+		if err != nil {
+			panic(err)
+		}
+		if err := out.plan(plan); err != nil {
+			panic(err)
+		}
+	case "foods":
+		list, err := foods.GetFoods()
+		if err != nil {
+			panic(err)
+		}
+		if err := out.foods(list); err != nil {
+			panic(err)
+		}
+	default:
+		panic("unknown command: " + command)
+	}
+}
+
+// parseDate разбирает флаг --date: пустая строка - сегодня (с заданным зерном seeded -
+// без учета календаря), "off" - без учета календаря
+func parseDate(value string, seeded bool) (time.Time, error) {
+	switch {
+	case value == "" && !seeded:
+		return time.Now(), nil
+	case value == "" || value == "off":
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(time.DateOnly, value, time.Local)
+}
+
+// formatDate отдает дату для флага --date, "off" - календарь не учитывается
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return "off"
+	}
+	return date.Format(time.DateOnly)
+}
+
+// offlineHistory не ограничивает запросы и не сохраняет историю
+type offlineHistory struct{}
+
+func (offlineHistory) SaveRequest(int64, []models.Food) error     { return nil }
+func (offlineHistory) SavePlanRequest(int64, []models.Food) error { return nil }
+func (offlineHistory) IsLimit(int64) (bool, error)                { return true, nil }
+
+// Блюдо в JSON выводе
+type foodJSON struct {
+	Name        string   `json:"name"`
+	Category    string   `json:"category"`
+	Tags        []string `json:"tags,omitempty"`
+	Ingredients []string `json:"ingredients,omitempty"`
+	Recipe      string   `json:"recipe,omitempty"`
+	Kcal        float64  `json:"kcal,omitempty"`
+	Seasons     []string `json:"seasons,omitempty"`
+	Weekdays    []string `json:"weekdays,omitempty"`
+}
+
+// output печатает результаты текстом или в JSON
+type output struct {
+	w          io.Writer
+	lang       i18n.Lang
+	categories []models.Category
+	json       bool
+}
+
+func (o output) dinner(foods []models.Food) error {
+	if o.json {
+		return o.encode(o.toJSON(foods))
+	}
+	text := formatter.New(formatter.Plain).Dinner(o.lang, foods)
+	if total, ok := models.TotalNutrition(foods); ok {
+		text += "\n" + i18n.T(o.lang, i18n.NutritionTotal, total.Kcal, total.Protein, total.Fat, total.Carbs)
+	}
+	_, err := fmt.Fprintln(o.w, text)
+	return err
+}
+
+func (o output) plan(plan [][]models.Food) error {
+	if o.json {
+		res := make([][]foodJSON, 0, len(plan))
+		for _, foods := range plan {
+			res = append(res, o.toJSON(foods))
+		}
+		return o.encode(res)
+	}
+	f := formatter.New(formatter.Plain)
+	for i, foods := range plan {
+		if _, err := fmt.Fprintf(o.w, "%d. %s\n", i+1, f.Dinner(o.lang, foods)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o output) foods(foods []models.Food) error {
+	if o.json {
+		return o.encode(o.toJSON(foods))
+	}
+	for _, food := range foods {
+		line := fmt.Sprintf("%s (%s)", i18n.FoodName(o.lang, food), o.category(food.Category))
+		if len(food.Tags) > 0 {
+			line += " [" + strings.Join(food.Tags, ", ") + "]"
+		}
+		if _, err := fmt.Fprintln(o.w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o output) category(id models.FootCategory) string {
+	for _, category := range o.categories {
+		if category.ID == id {
+			return i18n.CategoryName(o.lang, category)
+		}
+	}
+	return ""
+}
+
+func (o output) toJSON(foods []models.Food) []foodJSON {
+	res := make([]foodJSON, 0, len(foods))
+	for _, food := range foods {
+		res = append(res, foodJSON{
+			Name:        i18n.FoodName(o.lang, food),
+			Category:    o.category(food.Category),
+			Tags:        food.Tags,
+			Ingredients: food.Ingredients,
+			Recipe:      food.Recipe,
+			Kcal:        kcalOf(food),
+			Seasons:     models.SeasonNames(food.Seasons),
+			Weekdays:    models.WeekdayNames(food.Weekdays),
+		})
+	}
+	return res
+}
+
+// kcalOf отдает калорийность порции, 0 - не указана
+func kcalOf(food models.Food) float64 {
+	if food.Nutrition == nil {
+		return 0
+	}
+	return food.Nutrition.Kcal
+}
+
+func (o output) encode(v any) error {
+	encoder := json.NewEncoder(o.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
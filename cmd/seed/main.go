@@ -0,0 +1,58 @@
+// Точка входа для загрузки начальных данных каталога блюд
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	seedservice "dinner/internal/services/seed"
+	storagesqlite "dinner/internal/storages/sqlite"
+
+	// Драйвер SQLite 3
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+	var storagePath, seedPath string
+
+	// Путь до файла БД
+	flag.StringVar(&storagePath, "storage-path", "", "path to storage")
+	// Путь до файла с начальными данными (YAML или JSON)
+	flag.StringVar(&seedPath, "seed-path", "", "path to seed file")
+	// Выполняем парсинг флагов
+	flag.Parse()
+
+	// Валидация параметров
+	if storagePath == "" {
+		panic("storage-path is required")
+	}
+	if seedPath == "" {
+		panic("seed-path is required")
+	}
+
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	seed, err := seedservice.Load(seedPath)
+	if err != nil {
+		panic(err)
+	}
+	storage, err := storagesqlite.New(log, storagePath)
+	if err != nil {
+		panic(err)
+	}
+
+	// Применяем начальные данные и выводим отчет
+	report, err := seedservice.New(log, storage).Apply(seed)
+	if err != nil {
+		panic(err)
+	}
+	for _, name := range report.Added {
+		fmt.Println("added:", name)
+	}
+	for _, name := range report.Updated {
+		fmt.Println("updated:", name)
+	}
+	fmt.Printf("added %d, updated %d, unchanged %d\n", len(report.Added), len(report.Updated), report.Unchanged)
+}
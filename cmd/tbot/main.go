@@ -2,12 +2,17 @@
 package main
 
 import (
+	"context"
 	"dinner/internal/app"
 	"dinner/internal/config"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+
+	// База часовых поясов для /tz, если на сервере ее нет
+	_ "time/tzdata"
 )
 
 func main() {
@@ -26,11 +31,18 @@ func main() {
 	application := app.New(logger, token, cfg)
 	// Запускаем бота на прослушивание в горутине
 	go application.Bot.Run()
+	// Запускаем HTTP сервер с проверками состояния и метриками
+	go application.HTTP.Run()
 
 	// Ожидаем от системы команды на остановку
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 	sign := <-stop
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := application.HTTP.Stop(ctx); err != nil {
+		logger.Error("http server stop error", slog.Any("error", err))
+	}
 	logger.Warn("application stopped", slog.String("signal", sign.String()))
 }
 
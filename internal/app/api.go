@@ -0,0 +1,32 @@
+package app
+
+import (
+	"dinner/internal/config"
+	restapi "dinner/internal/restApi"
+	adminservice "dinner/internal/services/admin"
+	catalogservice "dinner/internal/services/catalog"
+	dinnerservice "dinner/internal/services/dinner"
+	householdservice "dinner/internal/services/household"
+	leftoversservice "dinner/internal/services/leftovers"
+	preferencesservice "dinner/internal/services/preferences"
+	statsservice "dinner/internal/services/stats"
+	storagesqlite "dinner/internal/storages/sqlite"
+	"log/slog"
+)
+
+// NewAPI при помощи конфига создает HTTP JSON API без Telegram бота.
+// API работает с той же БД, поэтому история и лимиты общие с ботом.
+func NewAPI(log *slog.Logger, config *config.Config) *restapi.RestAPI {
+	storage, err := storagesqlite.New(log, config.StoragePath)
+	if err != nil {
+		panic(err)
+	}
+	dinner := dinnerservice.New(log, storage, storage, storage, storage, dinnerservice.NewSource(config.Seed))
+	catalog := catalogservice.New(log, storage)
+	stats := statsservice.New(log, storage)
+	admin := adminservice.New(log, config.Admins, storage)
+	prefs := preferencesservice.New(log, storage)
+	leftovers := leftoversservice.New(log, storage)
+	households := householdservice.New(log, storage)
+	return restapi.New(log, config.APIAddress, config.APIKeys, dinner, catalog, stats, admin, prefs, leftovers, households)
+}
@@ -3,7 +3,14 @@ package app
 
 import (
 	"dinner/internal/config"
+	httpserver "dinner/internal/httpServer"
+	adminservice "dinner/internal/services/admin"
+	catalogservice "dinner/internal/services/catalog"
 	dinnerservice "dinner/internal/services/dinner"
+	householdservice "dinner/internal/services/household"
+	leftoversservice "dinner/internal/services/leftovers"
+	preferencesservice "dinner/internal/services/preferences"
+	statsservice "dinner/internal/services/stats"
 	storagesqlite "dinner/internal/storages/sqlite"
 	telegrambot "dinner/internal/telegramBot"
 	"log/slog"
@@ -13,7 +20,8 @@ import (
 
 // Приложение бота
 type App struct {
-	Bot *telegrambot.TelegramBot
+	Bot  *telegrambot.TelegramBot
+	HTTP *httpserver.HTTPServer
 }
 
 // New при помощи конфига создает и настраивает бота
@@ -24,10 +32,25 @@ func New(log *slog.Logger, token string, config *config.Config) *App {
 		panic(err)
 	}
 	// Создает сервисный слой в виде сервиса dinner
-	dinner := dinnerservice.New(log, storage, storage)
+	dinner := dinnerservice.New(log, storage, storage, storage, storage, dinnerservice.NewSource(config.Seed))
+	// Создает сервис импорта и экспорта каталога блюд
+	catalog := catalogservice.New(log, storage)
+	// Создает сервис личной статистики
+	stats := statsservice.New(log, storage)
+	// Создает сервис пользователей и команд администраторов
+	admin := adminservice.New(log, config.Admins, storage)
+	// Создает сервис настроек пользователей
+	prefs := preferencesservice.New(log, storage)
+	// Создает сервис остатков ужинов
+	leftovers := leftoversservice.New(log, storage)
+	// Создает сервис семей
+	households := householdservice.New(log, storage)
 	// Создает инфраструктурный слой в вибе бота
-	bot := telegrambot.New(log, token, config.Timeout, dinner)
+	bot := telegrambot.New(log, token, config.Timeout, dinner, catalog, stats, admin, prefs, leftovers, households)
+	// Создает HTTP сервер с проверками состояния и метриками
+	http := httpserver.New(log, config.HTTPAddress, storage, bot)
 	return &App{
-		Bot: bot,
+		Bot:  bot,
+		HTTP: http,
 	}
 }
@@ -19,7 +19,18 @@ const (
 type Config struct {
 	Env         string `yaml:"env" env-required:"local"`
 	StoragePath string `yaml:"storage_path" env-required:"./data"`
-	Timeout     int    `yaml:"timeout" env-default:30`
+	Timeout     int    `yaml:"timeout" env-default:"30"`
+	// Адрес HTTP сервера с /healthz, /readyz и /metrics
+	HTTPAddress string `yaml:"http_address" env-default:":8080"`
+	// Зерно генератора случайных чисел, 0 - случайное.
+	// Фиксированное зерно делает подбор ужинов воспроизводимым.
+	Seed uint64 `yaml:"seed" env-default:"0"`
+	// Адрес HTTP сервера с REST API
+	APIAddress string `yaml:"api_address" env-default:":8081"`
+	// Ключи REST API и id пользователей, от имени которых выполняются запросы
+	APIKeys map[string]int64 `yaml:"api_keys"`
+	// id пользователей Telegram с правами администратора
+	Admins []int64 `yaml:"admins"`
 }
 
 // MustLoadConfig загружает конфиг из файла в структуру Config
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Время года
+type Season int
+
+const (
+	Winter Season = iota + 1
+	Spring
+	Summer
+	Autumn
+)
+
+// Названия времен года в файлах каталога и в БД
+var seasonNames = map[Season]string{
+	Winter: "winter",
+	Spring: "spring",
+	Summer: "summer",
+	Autumn: "autumn",
+}
+
+// Дополнительные написания времен года
+var seasonAliases = map[string]Season{
+	"fall":  Autumn,
+	"зима":  Winter,
+	"весна": Spring,
+	"лето":  Summer,
+	"осень": Autumn,
+}
+
+// Короткие названия дней недели в файлах каталога и в БД
+var weekdayNames = map[time.Weekday]string{
+	time.Monday:    "mon",
+	time.Tuesday:   "tue",
+	time.Wednesday: "wed",
+	time.Thursday:  "thu",
+	time.Friday:    "fri",
+	time.Saturday:  "sat",
+	time.Sunday:    "sun",
+}
+
+// Дополнительные написания дней недели
+var weekdayAliases = map[string]time.Weekday{
+	"пн": time.Monday, "понедельник": time.Monday,
+	"вт": time.Tuesday, "вторник": time.Tuesday,
+	"ср": time.Wednesday, "среда": time.Wednesday,
+	"чт": time.Thursday, "четверг": time.Thursday,
+	"пт": time.Friday, "пятница": time.Friday,
+	"сб": time.Saturday, "суббота": time.Saturday,
+	"вс": time.Sunday, "воскресенье": time.Sunday,
+}
+
+// SeasonOf отдает время года для даты t (для северного полушария)
+func SeasonOf(t time.Time) Season {
+	switch t.Month() {
+	case time.December, time.January, time.February:
+		return Winter
+	case time.March, time.April, time.May:
+		return Spring
+	case time.June, time.July, time.August:
+		return Summer
+	}
+	return Autumn
+}
+
+func (s Season) String() string {
+	return seasonNames[s]
+}
+
+// ParseSeason разбирает время года: "winter", "fall", "зима" и т.п.
+func ParseSeason(value string) (Season, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for season, name := range seasonNames {
+		if name == value {
+			return season, true
+		}
+	}
+	season, ok := seasonAliases[value]
+	return season, ok
+}
+
+// WeekdayName отдает короткое название дня недели: "mon", "tue" и т.п.
+func WeekdayName(day time.Weekday) string {
+	return weekdayNames[day]
+}
+
+// ParseWeekday разбирает день недели: "thu", "thursday", "чт", "четверг"
+func ParseWeekday(value string) (time.Weekday, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for day, name := range weekdayNames {
+		if name == value || strings.ToLower(day.String()) == value {
+			return day, true
+		}
+	}
+	day, ok := weekdayAliases[value]
+	return day, ok
+}
+
+// InSeason сообщает, подходит ли блюдо для времени года season.
+// Блюда без указанных сезонов подходят круглый год.
+func (f Food) InSeason(season Season) bool {
+	return len(f.Seasons) == 0 || slices.Contains(f.Seasons, season)
+}
+
+// PreferredOn сообщает, что блюдо предпочтительно готовить в день недели day
+func (f Food) PreferredOn(day time.Weekday) bool {
+	return slices.Contains(f.Weekdays, day)
+}
+
+// ParseSeasons разбирает список времен года
+func ParseSeasons(values []string) ([]Season, error) {
+	var res []Season
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		season, ok := ParseSeason(value)
+		if !ok {
+			return nil, fmt.Errorf("unknown season %q", value)
+		}
+		res = append(res, season)
+	}
+	return res, nil
+}
+
+// ParseWeekdays разбирает список дней недели
+func ParseWeekdays(values []string) ([]time.Weekday, error) {
+	var res []time.Weekday
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		day, ok := ParseWeekday(value)
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", value)
+		}
+		res = append(res, day)
+	}
+	return res, nil
+}
+
+// SeasonNames отдает названия времен года
+func SeasonNames(seasons []Season) []string {
+	if len(seasons) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(seasons))
+	for _, season := range seasons {
+		res = append(res, season.String())
+	}
+	return res
+}
+
+// WeekdayNames отдает короткие названия дней недели
+func WeekdayNames(days []time.Weekday) []string {
+	if len(days) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(days))
+	for _, day := range days {
+		res = append(res, WeekdayName(day))
+	}
+	return res
+}
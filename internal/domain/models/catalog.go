@@ -0,0 +1,17 @@
+package models
+
+// Изменение блюда при импорте каталога
+type FoodUpdate struct {
+	Old Food
+	New Food
+}
+
+// Разница между каталогом в БД и импортируемым каталогом
+type CatalogDiff struct {
+	// Новые блюда
+	Added []Food
+	// Блюда, у которых изменились категория, теги или ингредиенты
+	Updated []FoodUpdate
+	// Количество блюд без изменений
+	Unchanged int
+}
@@ -1,5 +1,11 @@
 package models
 
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
 type FootCategory int
 
 // Типы еды
@@ -12,6 +18,76 @@ const (
 
 // Описание одного блюда
 type Food struct {
+	ID       int64
 	Name     string
 	Category FootCategory
+	// Теги блюда (например: "острое", "постное")
+	Tags []string
+	// Ингредиенты блюда
+	Ingredients []string
+	// Переводы названия по коду языка (например: "en")
+	Names map[string]string
+	// Ссылка на рецепт
+	Recipe string
+	// Пищевая ценность порции, nil - не указана
+	Nutrition *Nutrition
+	// Времена года, в которые блюдо уместно, пустой - круглый год
+	Seasons []Season
+	// Дни недели, в которые блюдо предпочтительно (рыба в четверг)
+	Weekdays []time.Weekday
+	// Фото блюда: file_id в Telegram, пустой - фото нет
+	Photo string
+	// Примерная цена порции в рублях, 0 - считается по ценам ингредиентов (см. Prices)
+	Price int
+}
+
+// Mentions сообщает, что item - название блюда, один из его тегов или ингредиентов
+// (без учета регистра)
+func (f Food) Mentions(item string) bool {
+	equal := func(value string) bool { return strings.EqualFold(value, item) }
+	return equal(f.Name) || slices.ContainsFunc(f.Tags, equal) || slices.ContainsFunc(f.Ingredients, equal)
+}
+
+// NormalizeList приводит теги или ингредиенты к виду, в котором они хранятся:
+// нижний регистр без пробелов по краям, без пустых значений и повторов, по алфавиту.
+// Пустой список - nil.
+func NormalizeList(values []string) []string {
+	res := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			res = append(res, value)
+		}
+	}
+	slices.Sort(res)
+	res = slices.Compact(res)
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+// Роль блюда в ужине
+type Role int
+
+const (
+	// Основное блюдо
+	RoleMain Role = iota + 1
+	// Гарнир к основному блюду
+	RoleSide
+	// Закуска
+	RoleStarter
+	// Первое блюдо
+	RoleSoup
+)
+
+// Блюдо ужина вместе с его ролью
+type Course struct {
+	Role Role
+	Food Food
+}
+
+// Описание категории блюд
+type Category struct {
+	ID   FootCategory
+	Name string
 }
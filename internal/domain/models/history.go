@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Один запрос ужина из истории пользователя
+type HistoryEntry struct {
+	Time  time.Time
+	Foods []Food
+	// Запрос нескольких ужинов сразу (план, заготовка или варианты на выбор), а не одного ужина
+	Plan bool
+}
+
+// Количество предложений блюда
+type FoodCount struct {
+	Food  Food
+	Count int
+}
+
+// Количество предложенных блюд категории
+type CategoryCount struct {
+	Category Category
+	Count    int
+}
+
+// Личная статистика пользователя
+type UserStats struct {
+	// Всего запросов
+	TotalRequests int
+	// Запросов в текущем месяце
+	MonthRequests int
+	// Чаще всего предложенные блюда
+	TopFoods []FoodCount
+	// Реже всего предложенные блюда
+	BottomFoods []FoodCount
+	// Распределение предложенных блюд по категориям
+	Categories []CategoryCount
+	// Текущая серия дней подряд с запросами
+	CurrentStreak int
+	// Самая длинная серия дней подряд с запросами
+	LongestStreak int
+}
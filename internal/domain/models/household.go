@@ -0,0 +1,23 @@
+package models
+
+import "slices"
+
+// Общий аккаунт семьи: история, лимит запросов и остатки ужинов
+// у всех участников общие и хранятся от имени владельца
+type Household struct {
+	ID      int64
+	OwnerID int64
+	// Код приглашения для ссылки t.me/<бот>?start=join_<код>
+	Code    string
+	Members []User
+}
+
+// IsOwner сообщает, что userId - владелец семьи
+func (h Household) IsOwner(userId int64) bool {
+	return h.OwnerID == userId
+}
+
+// IsMember сообщает, что userId состоит в семье
+func (h Household) IsMember(userId int64) bool {
+	return slices.ContainsFunc(h.Members, func(user User) bool { return user.ID == userId })
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Остатки ужина, которые доедают несколько дней
+type Leftover struct {
+	Foods []Food
+	// До этого момента остатки предлагаются вместо нового ужина
+	Until time.Time
+}
+
+// Active сообщает, что на момент now остатки еще не закончились
+func (l Leftover) Active(now time.Time) bool {
+	return len(l.Foods) > 0 && now.Before(l.Until)
+}
@@ -0,0 +1,34 @@
+package models
+
+// План заготовки: несколько ужинов, которые готовят за один раз на несколько дней
+type MealPrep struct {
+	// Ужины в порядке выбора
+	Dinners [][]Food
+	// Сколько порций готовится из каждого блюда
+	Portions int
+	// Блюда в порядке приготовления
+	CookOrder []Food
+	// Список покупок, сначала ингредиенты, общие для нескольких блюд
+	Shopping []ShoppingItem
+}
+
+// Ингредиент в списке покупок
+type ShoppingItem struct {
+	Ingredient string
+	// Сколько блюд заготовки используют ингредиент
+	Dishes int
+	// На сколько порций нужен ингредиент
+	Portions int
+}
+
+// Shared отдает ингредиенты, общие для нескольких блюд:
+// их покупают и подготавливают (моют, чистят, режут) один раз на все блюда
+func (p MealPrep) Shared() []string {
+	shared := []string{}
+	for _, item := range p.Shopping {
+		if item.Dishes > 1 {
+			shared = append(shared, item.Ingredient)
+		}
+	}
+	return shared
+}
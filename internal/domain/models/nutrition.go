@@ -0,0 +1,50 @@
+package models
+
+// Пищевая ценность одной порции блюда
+type Nutrition struct {
+	Kcal    float64
+	Protein float64
+	Fat     float64
+	Carbs   float64
+}
+
+// Add отдает сумму пищевой ценности n и other
+func (n Nutrition) Add(other Nutrition) Nutrition {
+	return Nutrition{
+		Kcal:    n.Kcal + other.Kcal,
+		Protein: n.Protein + other.Protein,
+		Fat:     n.Fat + other.Fat,
+		Carbs:   n.Carbs + other.Carbs,
+	}
+}
+
+// TotalNutrition суммирует пищевую ценность блюд ужина.
+// ok == false, если хотя бы для одного блюда она не указана.
+func TotalNutrition(foods []Food) (total Nutrition, ok bool) {
+	if len(foods) == 0 {
+		return total, false
+	}
+	for _, food := range foods {
+		if food.Nutrition == nil {
+			return Nutrition{}, false
+		}
+		total = total.Add(*food.Nutrition)
+	}
+	return total, true
+}
+
+// Диапазон калорий ужина. Граница 0 означает, что ограничения нет.
+type KcalRange struct {
+	Min float64
+	Max float64
+}
+
+// IsZero сообщает, что диапазон не задан
+func (r KcalRange) IsZero() bool {
+	return r.Min == 0 && r.Max == 0
+}
+
+// Contains проверяет, что kcal попадает в диапазон
+func (r KcalRange) Contains(kcal float64) bool {
+	return (r.Min == 0 || kcal >= r.Min) && (r.Max == 0 || kcal <= r.Max)
+}
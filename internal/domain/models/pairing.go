@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Веса сочетаний мяса и гарнира.
+// Вес - во сколько раз чаще сочетание выбирается по сравнению с обычным.
+const (
+	// Сочетание не предлагается
+	PairForbidden = 0
+	// Обычное сочетание, вес всех пар, которых нет в таблице
+	PairAllowed = 1
+	// Удачное сочетание
+	PairPreferred = 3
+)
+
+// Максимальный вес сочетания
+const MaxPairWeight = 10
+
+// Сочетание мяса и гарнира
+type Pairing struct {
+	MeatID int64
+	SideID int64
+	Weight int
+}
+
+// Таблица сочетаний по паре (id мяса, id гарнира)
+type Pairings map[[2]int64]int
+
+// NewPairings собирает таблицу сочетаний из списка
+func NewPairings(list []Pairing) Pairings {
+	res := make(Pairings, len(list))
+	for _, p := range list {
+		res[[2]int64{p.MeatID, p.SideID}] = p.Weight
+	}
+	return res
+}
+
+// Weight отдает вес сочетания блюд a и b в любом порядке.
+// Для пар, которых нет в таблице, и для блюд других категорий - PairAllowed.
+func (p Pairings) Weight(a, b Food) int {
+	if a.Category == SideDish && b.Category == Meat {
+		a, b = b, a
+	}
+	if a.Category != Meat || b.Category != SideDish {
+		return PairAllowed
+	}
+	if weight, ok := p[[2]int64{a.ID, b.ID}]; ok {
+		return weight
+	}
+	return PairAllowed
+}
+
+// ParsePairWeight разбирает вес сочетания: "forbid", "allow", "prefer"
+// (или "запрет", "можно", "хорошо") либо число от 0 до MaxPairWeight
+func ParsePairWeight(value string) (int, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "forbid", "forbidden", "запрет", "нельзя":
+		return PairForbidden, true
+	case "allow", "allowed", "можно":
+		return PairAllowed, true
+	case "prefer", "preferred", "хорошо":
+		return PairPreferred, true
+	}
+	weight, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || weight < PairForbidden || weight > MaxPairWeight {
+		return 0, false
+	}
+	return weight, true
+}
@@ -0,0 +1,12 @@
+package models
+
+// Ужин, который можно приготовить из имеющихся продуктов
+type CookOption struct {
+	Foods []Food
+	// Ингредиенты, которые есть
+	Have []string
+	// Ингредиенты, которых не хватает
+	Missing []string
+	// Доля имеющихся ингредиентов, от 0 до 1
+	Coverage float64
+}
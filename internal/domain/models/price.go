@@ -0,0 +1,44 @@
+package models
+
+import "strings"
+
+// Примерные цены ингредиентов на одну порцию блюда в рублях
+// по названию ингредиента в нижнем регистре
+type Prices map[string]int
+
+// PriceKey отдает ключ ингредиента в Prices
+func PriceKey(ingredient string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(ingredient)), "ё", "е")
+}
+
+// FoodCost отдает примерную стоимость порции блюда: цену блюда, если она указана,
+// иначе сумму известных цен его ингредиентов.
+// ok == false, если неизвестны ни цена блюда, ни цена хотя бы одного ингредиента.
+func (p Prices) FoodCost(food Food) (cost int, ok bool) {
+	if food.Price > 0 {
+		return food.Price, true
+	}
+	for _, ingredient := range food.Ingredients {
+		if price, found := p[PriceKey(ingredient)]; found {
+			cost += price
+			ok = true
+		}
+	}
+	return cost, ok
+}
+
+// DinnerCost суммирует стоимость порций блюд ужина.
+// ok == false, если хотя бы для одного блюда стоимость неизвестна.
+func (p Prices) DinnerCost(foods []Food) (total int, ok bool) {
+	if len(foods) == 0 {
+		return 0, false
+	}
+	for _, food := range foods {
+		cost, ok := p.FoodCost(food)
+		if !ok {
+			return 0, false
+		}
+		total += cost
+	}
+	return total, true
+}
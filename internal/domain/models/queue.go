@@ -0,0 +1,33 @@
+package models
+
+// Блюдо в очереди режима "все блюда по разу"
+type QueueItem struct {
+	FoodID int64
+	// Блюдо уже предлагалось в текущем круге
+	Served bool
+}
+
+// Очередь блюд пользователя в порядке подачи
+type FoodQueue []QueueItem
+
+// Remaining отдает, сколько блюд очереди еще не предлагалось
+func (q FoodQueue) Remaining() int {
+	count := 0
+	for _, item := range q {
+		if !item.Served {
+			count++
+		}
+	}
+	return count
+}
+
+// MarkServed отмечает блюда foods предложенными
+func (q FoodQueue) MarkServed(foods []Food) {
+	for i := range q {
+		for _, food := range foods {
+			if q[i].FoodID == food.ID {
+				q[i].Served = true
+			}
+		}
+	}
+}
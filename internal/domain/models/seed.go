@@ -0,0 +1,25 @@
+package models
+
+// Начальные данные каталога блюд
+type Seed struct {
+	Categories []Category
+	Foods      []Food
+	Pairings   []SeedPairing
+}
+
+// Сочетание мяса и гарнира в начальных данных, блюда указываются по названию
+type SeedPairing struct {
+	Meat   string
+	Side   string
+	Weight int
+}
+
+// Отчет о применении начальных данных
+type SeedReport struct {
+	// Добавленные записи
+	Added []string
+	// Измененные записи
+	Updated []string
+	// Количество записей без изменений
+	Unchanged int
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Шаги отбора блюд при подборе ужина
+const (
+	// Все блюда каталога
+	TraceCatalog = "catalog"
+	// Без блюд, попадающих под ограничения в питании
+	TraceRestrictions = "restrictions"
+	// Блюда по сезону
+	TraceSeason = "season"
+	// Варианты с учетом предпочтительного дня недели (блюдо может повторяться)
+	TraceWeekday = "weekday"
+	// Все возможные ужины из оставшихся блюд
+	TraceDinners = "dinners"
+	// Ужины, подходящие под цель по калориям
+	TraceKcal = "kcal"
+	// Ужины, подходящие по стоимости (бюджет или дешевый ужин)
+	TraceBudget = "budget"
+)
+
+// Что выбиралось случайно
+const (
+	// Первое блюдо ужина среди всех вариантов
+	DrawDish = "dish"
+	// Пара к мясу или гарниру с учетом весов сочетаний
+	DrawPair = "pair"
+	// Закуска и суп ужина из нескольких подач
+	DrawStarter = "starter"
+	DrawSoup    = "soup"
+)
+
+// Шаг отбора: сколько вариантов осталось после него
+type TraceStep struct {
+	Name string
+	Pool int
+}
+
+// Случайный выбор с весами
+type TraceDraw struct {
+	Name string
+	// Выбранные блюда
+	Chosen []Food
+	// Вес выбранного варианта и сумма весов всех вариантов:
+	// шанс выбора - Weight из Total
+	Weight int
+	Total  int
+	// Количество вариантов
+	Options int
+	// Выпавшее случайное число из [0, Total)
+	Roll int
+}
+
+// Объяснение подбора ужина: размеры выборки после каждого шага и случайные выборы
+type Trace struct {
+	// Зерно подбора, 0 - неизвестно
+	Seed uint64
+	// Стратегия выбора ужина
+	Strategy string
+	Steps    []TraceStep
+	Draws    []TraceDraw
+}
+
+// AddStep записывает шаг отбора. У nil ничего не записывается.
+func (t *Trace) AddStep(name string, pool int) {
+	if t == nil {
+		return
+	}
+	t.Steps = append(t.Steps, TraceStep{Name: name, Pool: pool})
+}
+
+// AddDraw записывает случайный выбор. У nil ничего не записывается.
+func (t *Trace) AddDraw(draw TraceDraw) {
+	if t == nil {
+		return
+	}
+	t.Draws = append(t.Draws, draw)
+}
+
+// Step отдает шаг отбора по названию
+func (t Trace) Step(name string) (TraceStep, bool) {
+	for _, step := range t.Steps {
+		if step.Name == name {
+			return step, true
+		}
+	}
+	return TraceStep{}, false
+}
+
+// LogValue записывает объяснение в лог одной строкой на шаги и на выборы
+func (t Trace) LogValue() slog.Value {
+	steps := make([]string, 0, len(t.Steps))
+	for _, step := range t.Steps {
+		steps = append(steps, fmt.Sprintf("%s=%d", step.Name, step.Pool))
+	}
+	draws := make([]string, 0, len(t.Draws))
+	for _, draw := range t.Draws {
+		names := make([]string, 0, len(draw.Chosen))
+		for _, food := range draw.Chosen {
+			names = append(names, food.Name)
+		}
+		draws = append(draws, fmt.Sprintf("%s=%s %d/%d roll=%d", draw.Name, strings.Join(names, "+"), draw.Weight, draw.Total, draw.Roll))
+	}
+	return slog.GroupValue(
+		slog.Uint64("seed", t.Seed),
+		slog.String("strategy", t.Strategy),
+		slog.String("steps", strings.Join(steps, " ")),
+		slog.String("draws", strings.Join(draws, "; ")),
+	)
+}
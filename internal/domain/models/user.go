@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Пользователь бота
+type User struct {
+	ID       int64
+	ChatID   int64
+	UserName string
+	LastSeen time.Time
+	Banned   bool
+}
+
+// Количество пользователей бота
+type UsersCount struct {
+	// Всего пользователей
+	Total int
+	// Активных за последнюю неделю
+	Active int
+	// Заблокированных
+	Banned int
+}
+
+// Запись журнала действий администраторов
+type AuditEntry struct {
+	AdminID int64
+	Action  string
+	Args    string
+	Time    time.Time
+}
@@ -0,0 +1,80 @@
+// Пакет HTTP сервера с проверками состояния и метриками приложения
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Таймаут чтения заголовков запроса
+const readHeaderTimeout = 5 * time.Second
+
+type HTTPServer struct {
+	log    *slog.Logger
+	server *http.Server
+}
+
+// Проверка доступности БД
+type Pinger interface {
+	Ping() error
+}
+
+// Проверка готовности бота к работе
+type Readiness interface {
+	Ready() bool
+}
+
+// New Конструктор сервера
+// log *slog.Logger - логгер
+// address string - адрес, на котором слушает сервер
+// db Pinger - проверка доступности БД для /healthz
+// bot Readiness - проверка подключения к Telegram для /readyz
+func New(log *slog.Logger, address string, db Pinger, bot Readiness) *HTTPServer {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		if err := db.Ping(); err != nil {
+			log.Error("health check failed", slog.Any("error", err))
+			http.Error(w, "storage unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !bot.Ready() {
+			http.Error(w, "telegram not connected", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+	mux.Handle("GET /metrics", promhttp.Handler())
+
+	return &HTTPServer{
+		log: log,
+		server: &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+// Run запускает сервер и блокируется до его остановки
+func (s *HTTPServer) Run() {
+	const op = "HTTPServer.Run"
+	log := s.log.With(slog.String("op", op))
+
+	log.Info("http server started", slog.String("address", s.server.Addr))
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		panic(err)
+	}
+}
+
+// Stop останавливает сервер, дожидаясь завершения текущих запросов
+func (s *HTTPServer) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,181 @@
+// Пакет форматирования сообщений с составом ужина
+// в виде обычного текста, MarkdownV2 или HTML.
+package formatter
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/i18n"
+	"html"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Режим разметки сообщения
+type Mode int
+
+const (
+	Plain Mode = iota
+	MarkdownV2
+	HTML
+)
+
+// Тег блюда, название которого нельзя писать со строчной буквы (например, "Цезарь")
+const ProperNounTag = "proper-noun"
+
+// Эмодзи категорий блюд
+var categoryEmoji = map[models.FootCategory]string{
+	models.Soup:     "🍲",
+	models.Salad:    "🥗",
+	models.Meat:     "🍖",
+	models.SideDish: "🍚",
+}
+
+// Порядок подачи блюд
+var roleOrder = []models.Role{models.RoleStarter, models.RoleSoup, models.RoleMain, models.RoleSide}
+
+// Названия ролей в сообщениях
+var roleLabels = map[models.Role]i18n.Key{
+	models.RoleStarter: i18n.RoleStarter,
+	models.RoleSoup:    i18n.RoleSoup,
+	models.RoleMain:    i18n.RoleMain,
+	models.RoleSide:    i18n.RoleSide,
+}
+
+// Символы, которые нужно экранировать в MarkdownV2
+const markdownSpecial = "_*[]()~`>#+-=|{}.!\\"
+
+type Formatter struct {
+	mode Mode
+}
+
+// New - конструктор форматтера с режимом разметки mode
+func New(mode Mode) *Formatter {
+	return &Formatter{mode: mode}
+}
+
+// ParseMode отдает режим разметки для Telegram
+func (f *Formatter) ParseMode() string {
+	switch f.mode {
+	case MarkdownV2:
+		return "MarkdownV2"
+	case HTML:
+		return "HTML"
+	}
+	return ""
+}
+
+// Courses раскладывает блюда ужина по ролям в порядке подачи.
+// Единственное блюдо всегда основное.
+func Courses(foods []models.Food) []models.Course {
+	courses := make([]models.Course, 0, len(foods))
+	for _, food := range foods {
+		role := models.RoleMain
+		switch food.Category {
+		case models.SideDish:
+			role = models.RoleSide
+		case models.Salad:
+			role = models.RoleStarter
+		case models.Soup:
+			role = models.RoleSoup
+		}
+		if len(foods) == 1 {
+			role = models.RoleMain
+		}
+		courses = append(courses, models.Course{Role: role, Food: food})
+	}
+	slices.SortStableFunc(courses, func(a, b models.Course) int {
+		return slices.Index(roleOrder, a.Role) - slices.Index(roleOrder, b.Role)
+	})
+	return courses
+}
+
+// Dinner формирует сообщение с составом ужина на языке lang.
+// Основное блюдо с гарниром пишутся одной строкой ("Котлеты и рис"),
+// ужин из нескольких подач - по строке на каждую.
+func (f *Formatter) Dinner(lang i18n.Lang, foods []models.Food) string {
+	courses := Courses(foods)
+	if isSimple(courses) {
+		parts := make([]string, 0, len(courses))
+		for i, course := range courses {
+			parts = append(parts, f.dish(lang, course.Food, i > 0))
+		}
+		return i18n.Join(lang, parts)
+	}
+
+	lines := make([]string, 0, len(courses))
+	for _, course := range courses {
+		label := f.escape(i18n.T(lang, roleLabels[course.Role]) + ":")
+		lines = append(lines, label+" "+f.dish(lang, course.Food, false))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isSimple проверяет, что ужин - одно блюдо или основное блюдо с гарниром
+func isSimple(courses []models.Course) bool {
+	if len(courses) == 1 {
+		return true
+	}
+	return len(courses) == 2 && courses[0].Role == models.RoleMain && courses[1].Role == models.RoleSide
+}
+
+// dish формирует описание одного блюда: эмодзи категории, название и ссылку на рецепт.
+// Если lower == true, название пишется со строчной буквы.
+func (f *Formatter) dish(lang i18n.Lang, food models.Food, lower bool) string {
+	name := i18n.FoodName(lang, food)
+	if lower {
+		name = lowerFirst(name, food)
+	}
+	text := f.escape(name)
+	if food.Recipe != "" {
+		text = f.link(name, food.Recipe)
+	}
+	if emoji, ok := categoryEmoji[food.Category]; ok {
+		text = emoji + " " + text
+	}
+	return text
+}
+
+// lowerFirst переводит первую букву названия в нижний регистр.
+// Имена собственные (тег ProperNounTag) и аббревиатуры не меняются.
+func lowerFirst(name string, food models.Food) string {
+	runes := []rune(name)
+	if len(runes) == 0 || slices.Contains(food.Tags, ProperNounTag) {
+		return name
+	}
+	if len(runes) > 1 && unicode.IsUpper(runes[1]) {
+		return name
+	}
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// link формирует ссылку с текстом text на адрес url
+func (f *Formatter) link(text string, url string) string {
+	switch f.mode {
+	case MarkdownV2:
+		url = strings.NewReplacer(`\`, `\\`, `)`, `\)`).Replace(url)
+		return "[" + f.escape(text) + "](" + url + ")"
+	case HTML:
+		return `<a href="` + html.EscapeString(url) + `">` + html.EscapeString(text) + `</a>`
+	}
+	return text + " (" + url + ")"
+}
+
+// escape экранирует текст для режима разметки
+func (f *Formatter) escape(text string) string {
+	switch f.mode {
+	case MarkdownV2:
+		var sb strings.Builder
+		for _, r := range text {
+			if strings.ContainsRune(markdownSpecial, r) {
+				sb.WriteRune('\\')
+			}
+			sb.WriteRune(r)
+		}
+		return sb.String()
+	case HTML:
+		return html.EscapeString(text)
+	}
+	return text
+}
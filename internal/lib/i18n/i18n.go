@@ -0,0 +1,97 @@
+// Пакет локализации сообщений бота
+package i18n
+
+import (
+	"dinner/internal/domain/models"
+	"fmt"
+	"strings"
+)
+
+// Язык сообщений
+type Lang string
+
+const (
+	Ru Lang = "ru"
+	En Lang = "en"
+)
+
+// Язык по умолчанию, если язык пользователя не поддерживается
+const Default = Ru
+
+// Поддерживаемые языки
+var Supported = []Lang{Ru, En}
+
+// Parse определяет язык по коду из Telegram (например, "en" или "en-US")
+func Parse(code string) (Lang, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	for _, lang := range Supported {
+		if string(lang) == code {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// Resolve выбирает язык пользователя: сначала выбранный командой /lang,
+// затем язык клиента Telegram, иначе язык по умолчанию
+func Resolve(override string, languageCode string) Lang {
+	if lang, ok := Parse(override); ok {
+		return lang
+	}
+	if lang, ok := Parse(languageCode); ok {
+		return lang
+	}
+	return Default
+}
+
+// T отдает сообщение key на языке lang.
+// Если переданы args, они подставляются в сообщение как в fmt.Sprintf.
+func T(lang Lang, key Key, args ...any) string {
+	text, ok := messages[lang][key]
+	if !ok {
+		text, ok = messages[Default][key]
+	}
+	if !ok {
+		return string(key)
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// Join объединяет названия блюд по правилам языка:
+// "A", "A и B", "A, B и C"
+func Join(lang Lang, items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+	and := T(lang, And)
+	return strings.Join(items[:len(items)-1], ", ") + " " + and + " " + items[len(items)-1]
+}
+
+// FoodName отдает название блюда на языке lang, если есть перевод
+func FoodName(lang Lang, food models.Food) string {
+	if name, ok := food.Names[string(lang)]; ok && name != "" {
+		return name
+	}
+	return food.Name
+}
+
+// CategoryName отдает название категории на языке lang
+func CategoryName(lang Lang, category models.Category) string {
+	if lang == Default {
+		return category.Name
+	}
+	key := Key(fmt.Sprintf("category_%d", category.ID))
+	if text, ok := messages[lang][key]; ok {
+		return text
+	}
+	return category.Name
+}
@@ -0,0 +1,390 @@
+package i18n
+
+// Ключ сообщения
+type Key string
+
+// Ключи сообщений
+const (
+	And                  Key = "and"
+	LimitExceeded        Key = "limit_exceeded"
+	EmptyHistory         Key = "empty_history"
+	StatsRequests        Key = "stats_requests"
+	StatsStreak          Key = "stats_streak"
+	StatsTop             Key = "stats_top"
+	StatsBottom          Key = "stats_bottom"
+	StatsCategories      Key = "stats_categories"
+	LangCurrent          Key = "lang_current"
+	LangChanged          Key = "lang_changed"
+	LangUnknown          Key = "lang_unknown"
+	UnknownFormat        Key = "unknown_format"
+	ImportHint           Key = "import_hint"
+	FileTooLarge         Key = "file_too_large"
+	FileError            Key = "file_error"
+	ImportDryRun         Key = "import_dry_run"
+	UsersCount           Key = "users_count"
+	BroadcastUsage       Key = "broadcast_usage"
+	BroadcastStarted     Key = "broadcast_started"
+	BroadcastDone        Key = "broadcast_done"
+	UserIdUsage          Key = "user_id_usage"
+	LimitReset           Key = "limit_reset"
+	UserBanned           Key = "user_banned"
+	UserUnbanned         Key = "user_unbanned"
+	UserNotFound         Key = "user_not_found"
+	ActionDenied         Key = "action_denied"
+	RoleStarter          Key = "role_starter"
+	RoleSoup             Key = "role_soup"
+	RoleMain             Key = "role_main"
+	RoleSide             Key = "role_side"
+	CookUsage            Key = "cook_usage"
+	CookNothing          Key = "cook_nothing"
+	CookMissing          Key = "cook_missing"
+	CookComplete         Key = "cook_complete"
+	PrepUsage            Key = "prep_usage"
+	PrepTitle            Key = "prep_title"
+	PrepCookOrder        Key = "prep_cook_order"
+	PrepShopping         Key = "prep_shopping"
+	PrepItem             Key = "prep_item"
+	PrepShared           Key = "prep_shared"
+	PrepNoIngredients    Key = "prep_no_ingredients"
+	NutritionTotal       Key = "nutrition_total"
+	NoMatchingDinner     Key = "no_matching_dinner"
+	KcalCurrent          Key = "kcal_current"
+	KcalNotSet           Key = "kcal_not_set"
+	KcalChanged          Key = "kcal_changed"
+	KcalUsage            Key = "kcal_usage"
+	DinnerCost           Key = "dinner_cost"
+	BudgetCurrent        Key = "budget_current"
+	BudgetNotSet         Key = "budget_not_set"
+	BudgetChanged        Key = "budget_changed"
+	BudgetUsage          Key = "budget_usage"
+	PriceUsage           Key = "price_usage"
+	PriceSaved           Key = "price_saved"
+	PriceRemoved         Key = "price_removed"
+	PriceUnknown         Key = "price_unknown"
+	TzCurrent            Key = "tz_current"
+	TzNotSet             Key = "tz_not_set"
+	TzChanged            Key = "tz_changed"
+	TzUsage              Key = "tz_usage"
+	PairUsage            Key = "pair_usage"
+	PairEmpty            Key = "pair_empty"
+	PairSaved            Key = "pair_saved"
+	PairInvalid          Key = "pair_invalid"
+	PhotoUsage           Key = "photo_usage"
+	PhotoSaved           Key = "photo_saved"
+	PhotoRemoved         Key = "photo_removed"
+	LeftoversOffer       Key = "leftovers_offer"
+	LeftoversKept        Key = "leftovers_kept"
+	LeftoversNone        Key = "leftovers_none"
+	LeftoversCleared     Key = "leftovers_cleared"
+	LeftoversUsage       Key = "leftovers_usage"
+	LeftoversNoDinner    Key = "leftovers_no_dinner"
+	StartWelcome         Key = "start_welcome"
+	HouseholdNone        Key = "household_none"
+	HouseholdInfo        Key = "household_info"
+	HouseholdOwner       Key = "household_owner"
+	HouseholdCreated     Key = "household_created"
+	HouseholdJoined      Key = "household_joined"
+	HouseholdLeft        Key = "household_left"
+	HouseholdDeleted     Key = "household_deleted"
+	HouseholdRemoved     Key = "household_removed"
+	HouseholdAlready     Key = "household_already"
+	HouseholdInvalidCode Key = "household_invalid_code"
+	HouseholdUsage       Key = "household_usage"
+	AvoidCurrent         Key = "avoid_current"
+	AvoidHousehold       Key = "avoid_household"
+	AvoidNotSet          Key = "avoid_not_set"
+	AvoidChanged         Key = "avoid_changed"
+	WhyButton            Key = "why_button"
+	WhyExpired           Key = "why_expired"
+	WhyTitle             Key = "why_title"
+	WhyCatalog           Key = "why_catalog"
+	WhyRestrictions      Key = "why_restrictions"
+	WhySeason            Key = "why_season"
+	WhyWeekday           Key = "why_weekday"
+	WhyDinners           Key = "why_dinners"
+	WhyKcal              Key = "why_kcal"
+	WhyBudget            Key = "why_budget"
+	WhyDrawDish          Key = "why_draw_dish"
+	WhyDrawPair          Key = "why_draw_pair"
+	WhySeed              Key = "why_seed"
+	WhyMode              Key = "why_mode"
+	WhyDrawStarter       Key = "why_draw_starter"
+	WhyDrawSoup          Key = "why_draw_soup"
+	FindUsage            Key = "find_usage"
+	FindNothing          Key = "find_nothing"
+	FindResults          Key = "find_results"
+	FindGone             Key = "find_gone"
+	InlineLimit          Key = "inline_limit"
+	InlineNothing        Key = "inline_nothing"
+	ModeCurrent          Key = "mode_current"
+	ModeChanged          Key = "mode_changed"
+	ModeUsage            Key = "mode_usage"
+	RateUsage            Key = "rate_usage"
+	RateSaved            Key = "rate_saved"
+	RateCleared          Key = "rate_cleared"
+	RateUnknownFood      Key = "rate_unknown_food"
+	RateNone             Key = "rate_none"
+	RateList             Key = "rate_list"
+)
+
+// Каталог сообщений по языкам
+var messages = map[Lang]map[Key]string{
+	Ru: {
+		And:                  "и",
+		LimitExceeded:        "Лимит попыток исчерпан",
+		EmptyHistory:         "Вы еще не запрашивали ужин",
+		StatsRequests:        "Запросов в этом месяце: %d (всего %d)",
+		StatsStreak:          "Серия дней подряд: %d (рекорд %d)",
+		StatsTop:             "Чаще всего:",
+		StatsBottom:          "Реже всего:",
+		StatsCategories:      "По категориям:",
+		LangCurrent:          "Текущий язык: %s. Доступные: %s, auto - по языку Telegram",
+		LangChanged:          "Язык изменен",
+		LangUnknown:          "Неизвестный язык. Доступные: %s, auto",
+		UnknownFormat:        "Неизвестный формат, доступны: csv, json, yaml",
+		ImportHint:           "Отправьте файл каталога с подписью \"/import\"",
+		FileTooLarge:         "Файл слишком большой",
+		FileError:            "Ошибка в файле: %s",
+		ImportDryRun:         "Изменения не сохранены. Для сохранения отправьте файл с подписью \"/import apply\"",
+		UsersCount:           "Пользователей: %d\nАктивных за неделю: %d\nЗаблокированных: %d",
+		BroadcastUsage:       "Использование: /broadcast <текст>",
+		BroadcastStarted:     "Рассылка начата, получателей: %d",
+		BroadcastDone:        "Рассылка завершена, доставлено: %d из %d",
+		UserIdUsage:          "Использование: /%s <userId>",
+		LimitReset:           "Лимит сброшен",
+		UserBanned:           "Пользователь заблокирован",
+		UserUnbanned:         "Пользователь разблокирован",
+		UserNotFound:         "Пользователь не найден",
+		ActionDenied:         "Действие запрещено",
+		RoleStarter:          "Закуска",
+		RoleSoup:             "Первое",
+		RoleMain:             "Основное",
+		RoleSide:             "Гарнир",
+		CookUsage:            "Перечислите продукты через запятую: /cook курица, рис, лук",
+		CookNothing:          "Из этих продуктов ничего не получается, добавьте еще что-нибудь",
+		CookMissing:          "не хватает: %s",
+		CookComplete:         "все есть",
+		PrepUsage:            "Использование: /prep 3 4 - 3 ужина по 4 порции (до %d ужинов и %d порций)",
+		PrepTitle:            "Заготовка: ужинов %d, каждое блюдо на %d порц.",
+		PrepCookOrder:        "Порядок готовки:",
+		PrepShopping:         "Список покупок:",
+		PrepItem:             "%s - блюд: %d, порций: %d",
+		PrepShared:           "Общие ингредиенты подготовьте сразу для всех блюд: %s",
+		PrepNoIngredients:    "У блюд заготовки не указаны ингредиенты",
+		NutritionTotal:       "≈ %.0f ккал, белки %.0f г, жиры %.0f г, углеводы %.0f г",
+		NoMatchingDinner:     "Не нашлось ужина под заданные калории и ограничения. Они меняются командами /kcal и /avoid",
+		KcalCurrent:          "Цель ужина: %s ккал",
+		KcalNotSet:           "Цель по калориям не задана",
+		KcalChanged:          "Цель сохранена",
+		KcalUsage:            "Использование: /kcal 400-700, /kcal -600, /kcal 400- или /kcal off",
+		DinnerCost:           "≈ %d ₽ за порцию",
+		BudgetCurrent:        "Бюджет на ужины: %d ₽ в неделю, около %d ₽ на ужин",
+		BudgetNotSet:         "Бюджет на ужины не задан",
+		BudgetChanged:        "Бюджет сохранен",
+		BudgetUsage:          "Использование: /budget 3500 - бюджет на ужины за неделю в рублях, /budget off - без бюджета",
+		PriceUsage:           "Использование: /price Борщ 250 - цена порции блюда, /price курица 120 - цена ингредиента на порцию, 0 - удалить цену",
+		PriceSaved:           "Цена %s: %d ₽ за порцию",
+		PriceRemoved:         "Цена %s удалена",
+		PriceUnknown:         "В каталоге нет блюда или ингредиента %q",
+		TzCurrent:            "Часовой пояс: %s, сейчас %s",
+		TzNotSet:             "Часовой пояс не задан, используется время сервера: %s",
+		TzChanged:            "Часовой пояс сохранен",
+		TzUsage:              "Использование: /tz Europe/Moscow или /tz auto - время сервера",
+		PairUsage:            "Использование: /pair мясо; гарнир; forbid|allow|prefer|0-10",
+		PairEmpty:            "Все сочетания мяса и гарниров обычные",
+		PairSaved:            "Сочетание сохранено: %s",
+		PairInvalid:          "Нужны мясо и гарнир из каталога: %s",
+		PhotoUsage:           "Пришлите фото блюда с подписью /photo Борщ, /photo Борщ off - удалить фото",
+		PhotoSaved:           "Фото блюда %s сохранено",
+		PhotoRemoved:         "Фото блюда %s удалено",
+		LeftoversOffer:       "🍲 Доедаем: %s\nОстатков хватит по %s. Новый ужин: /dinner new",
+		LeftoversKept:        "Остатки сохранены по %[2]s: %[1]s. Пока они есть, /dinner предлагает доесть их без учета в лимите",
+		LeftoversNone:        "Остатков нет. Если ужин остался на потом: /leftovers 2 - доедать еще 2 дня",
+		LeftoversCleared:     "Остатки закончились",
+		LeftoversUsage:       "Использование: /leftovers 1-%d или /leftovers off",
+		LeftoversNoDinner:    "Сначала получите ужин командой /dinner",
+		StartWelcome:         "Привет! Подскажу, что приготовить на ужин: /dinner. Общий аккаунт для семьи: /household",
+		HouseholdNone:        "Вы не состоите в семье. Создать: /household create, затем отправьте ссылку-приглашение близким",
+		HouseholdInfo:        "Семья: %s\nПриглашение: %s",
+		HouseholdOwner:       "владелец",
+		HouseholdCreated:     "Семья создана. Отправьте близким ссылку-приглашение: %s",
+		HouseholdJoined:      "Вы в семье: %s. История, лимит запросов и остатки теперь общие, ограничения в питании (/avoid) учитываются для всех",
+		HouseholdLeft:        "Вы вышли из семьи",
+		HouseholdDeleted:     "Семья распущена",
+		HouseholdRemoved:     "Участник удален из семьи. Старая ссылка-приглашение больше не действует, новая: %s",
+		HouseholdAlready:     "Вы уже состоите в семье, сначала выйдите: /household leave",
+		HouseholdInvalidCode: "Приглашение не найдено, попросите новую ссылку",
+		HouseholdUsage:       "Использование: /household, /household create, /household leave или /household remove <userId>",
+		AvoidCurrent:         "Не предлагать: %s",
+		AvoidHousehold:       "С учетом семьи: %s",
+		AvoidNotSet:          "Ограничений нет. Пример: /avoid грибы, свинина",
+		AvoidChanged:         "Ограничения сохранены",
+		WhyButton:            "Почему?",
+		WhyExpired:           "Объяснение этого ужина уже недоступно",
+		WhyTitle:             "Как подбирался ужин:",
+		WhyCatalog:           "блюд в каталоге: %d",
+		WhyRestrictions:      "без ограничений в питании (/avoid): %d",
+		WhySeason:            "по сезону: %d",
+		WhyWeekday:           "вариантов с учетом дня недели (любимые блюда дня считаются трижды): %d",
+		WhyDinners:           "возможных ужинов: %d",
+		WhyKcal:              "ужинов под цель по калориям (/kcal): %d",
+		WhyBudget:            "ужинов по бюджету (/budget): %d",
+		WhyDrawDish:          "выбрано блюдо %s: шанс %d из %d, выпало %d",
+		WhyDrawPair:          "к нему %s: шанс %d из %d с учетом сочетаний, выпало %d",
+		WhySeed:              "Зерно подбора: %d",
+		WhyMode:              "Режим подбора: %s",
+		WhyDrawStarter:       "на закуску %s: шанс %d из %d, выпало %d",
+		WhyDrawSoup:          "на первое %s: шанс %d из %d, выпало %d",
+		FindUsage:            "Использование: /find курица",
+		FindNothing:          "По запросу %q ничего не нашлось",
+		FindResults:          "Нашлось блюд: %d. Выберите блюдо, остальное подберу к нему",
+		FindGone:             "Этого блюда уже нет в каталоге",
+		InlineLimit:          "Лимит запросов исчерпан",
+		InlineNothing:        "Нет подходящих ужинов",
+		ModeCurrent:          "Режим подбора: %s",
+		ModeChanged:          "Режим подбора: %s",
+		ModeUsage:            "/mode auto - режим по умолчанию",
+		RateUsage:            "Использование: /rate <блюдо> <1-5>, /rate <блюдо> 0 - убрать оценку",
+		RateSaved:            "Оценка блюда %s: %d",
+		RateCleared:          "Оценка блюда %s убрана",
+		RateUnknownFood:      "Блюдо %q не найдено",
+		RateNone:             "Вы еще не оценивали блюда",
+		RateList:             "Ваши оценки:",
+		"mode_random":        "случайное блюдо",
+		"mode_rating":        "чаще блюда с высокой оценкой (/rate)",
+		"mode_fresh":         "сначала то, что давно не готовили",
+		"mode_roundrobin":    "все блюда каталога по очереди",
+		"mode_shuffle":       "все блюда каталога по разу в случайном порядке",
+	},
+	En: {
+		And:                  "and",
+		LimitExceeded:        "Attempt limit exceeded",
+		EmptyHistory:         "You haven't asked for a dinner yet",
+		StatsRequests:        "Requests this month: %d (total %d)",
+		StatsStreak:          "Days in a row: %d (record %d)",
+		StatsTop:             "Most often:",
+		StatsBottom:          "Least often:",
+		StatsCategories:      "By category:",
+		LangCurrent:          "Current language: %s. Available: %s, auto - Telegram language",
+		LangChanged:          "Language changed",
+		LangUnknown:          "Unknown language. Available: %s, auto",
+		UnknownFormat:        "Unknown format, available: csv, json, yaml",
+		ImportHint:           "Send the catalogue file with the caption \"/import\"",
+		FileTooLarge:         "The file is too large",
+		FileError:            "Error in the file: %s",
+		ImportDryRun:         "Changes are not saved. To save them, send the file with the caption \"/import apply\"",
+		UsersCount:           "Users: %d\nActive this week: %d\nBanned: %d",
+		BroadcastUsage:       "Usage: /broadcast <text>",
+		BroadcastStarted:     "Broadcast started, recipients: %d",
+		BroadcastDone:        "Broadcast finished, delivered: %d of %d",
+		UserIdUsage:          "Usage: /%s <userId>",
+		LimitReset:           "Limit reset",
+		UserBanned:           "User banned",
+		UserUnbanned:         "User unbanned",
+		UserNotFound:         "User not found",
+		ActionDenied:         "Action denied",
+		RoleStarter:          "Starter",
+		RoleSoup:             "Soup",
+		RoleMain:             "Main",
+		RoleSide:             "Side",
+		CookUsage:            "List what you have, separated by commas: /cook chicken, rice, onion",
+		CookNothing:          "Nothing fits these ingredients, try adding more",
+		CookMissing:          "missing: %s",
+		CookComplete:         "you have everything",
+		PrepUsage:            "Usage: /prep 3 4 - 3 dinners of 4 portions (up to %d dinners and %d portions)",
+		PrepTitle:            "Meal prep: %d dinners, %d portions of each dish",
+		PrepCookOrder:        "Cook order:",
+		PrepShopping:         "Shopping list:",
+		PrepItem:             "%s - dishes: %d, portions: %d",
+		PrepShared:           "Prepare shared ingredients once for all dishes: %s",
+		PrepNoIngredients:    "The dishes have no ingredients listed",
+		NutritionTotal:       "≈ %.0f kcal, protein %.0f g, fat %.0f g, carbs %.0f g",
+		NoMatchingDinner:     "No dinner fits your calorie target and restrictions. Change them with /kcal and /avoid",
+		KcalCurrent:          "Dinner target: %s kcal",
+		KcalNotSet:           "No calorie target set",
+		KcalChanged:          "Target saved",
+		KcalUsage:            "Usage: /kcal 400-700, /kcal -600, /kcal 400- or /kcal off",
+		DinnerCost:           "≈ %d ₽ per portion",
+		BudgetCurrent:        "Dinner budget: %d ₽ a week, about %d ₽ per dinner",
+		BudgetNotSet:         "No dinner budget set",
+		BudgetChanged:        "Budget saved",
+		BudgetUsage:          "Usage: /budget 3500 - weekly dinner budget in rubles, /budget off - no budget",
+		PriceUsage:           "Usage: /price Borscht 250 - price of a dish portion, /price chicken 120 - ingredient price per portion, 0 removes the price",
+		PriceSaved:           "Price of %s: %d ₽ per portion",
+		PriceRemoved:         "Price of %s removed",
+		PriceUnknown:         "No dish or ingredient %q in the catalog",
+		TzCurrent:            "Time zone: %s, now %s",
+		TzNotSet:             "No time zone set, using server time: %s",
+		TzChanged:            "Time zone saved",
+		TzUsage:              "Usage: /tz Europe/London or /tz auto - server time",
+		PairUsage:            "Usage: /pair meat; side dish; forbid|allow|prefer|0-10",
+		PairEmpty:            "All meat and side dish pairings are default",
+		PairSaved:            "Pairing saved: %s",
+		PairInvalid:          "A meat and a side dish from the catalog are required: %s",
+		PhotoUsage:           "Send a dish photo captioned /photo Borscht, /photo Borscht off removes the photo",
+		PhotoSaved:           "Photo of %s saved",
+		PhotoRemoved:         "Photo of %s removed",
+		LeftoversOffer:       "🍲 Finishing the leftovers: %s\nThey last through %s. A new dinner: /dinner new",
+		LeftoversKept:        "Leftovers kept through %[2]s: %[1]s. Until then /dinner offers them without using your limit",
+		LeftoversNone:        "No leftovers. If the dinner lasts longer: /leftovers 2 - finish it over 2 more days",
+		LeftoversCleared:     "Leftovers are finished",
+		LeftoversUsage:       "Usage: /leftovers 1-%d or /leftovers off",
+		LeftoversNoDinner:    "Get a dinner with /dinner first",
+		StartWelcome:         "Hi! I'll suggest what to cook for dinner: /dinner. A shared account for your family: /household",
+		HouseholdNone:        "You are not in a household. Create one with /household create and send the invite link to your family",
+		HouseholdInfo:        "Household: %s\nInvite: %s",
+		HouseholdOwner:       "owner",
+		HouseholdCreated:     "Household created. Send the invite link to your family: %s",
+		HouseholdJoined:      "You joined the household: %s. History, request limit and leftovers are now shared, dietary restrictions (/avoid) apply to everyone",
+		HouseholdLeft:        "You left the household",
+		HouseholdDeleted:     "The household is disbanded",
+		HouseholdRemoved:     "The member is removed from the household. The old invite link no longer works, the new one: %s",
+		HouseholdAlready:     "You are already in a household, leave it first: /household leave",
+		HouseholdInvalidCode: "Invite not found, ask for a new link",
+		HouseholdUsage:       "Usage: /household, /household create, /household leave or /household remove <userId>",
+		AvoidCurrent:         "Never suggest: %s",
+		AvoidHousehold:       "Including the household: %s",
+		AvoidNotSet:          "No restrictions. Example: /avoid mushrooms, pork",
+		AvoidChanged:         "Restrictions saved",
+		WhyButton:            "Why?",
+		WhyExpired:           "The explanation for this dinner is no longer available",
+		WhyTitle:             "How the dinner was chosen:",
+		WhyCatalog:           "dishes in the catalogue: %d",
+		WhyRestrictions:      "without dietary restrictions (/avoid): %d",
+		WhySeason:            "in season: %d",
+		WhyWeekday:           "options counting the day of the week (favourite dishes of the day count three times): %d",
+		WhyDinners:           "possible dinners: %d",
+		WhyKcal:              "dinners within the calorie target (/kcal): %d",
+		WhyBudget:            "dinners within the budget (/budget): %d",
+		WhyDrawDish:          "picked %s: chance %d of %d, rolled %d",
+		WhyDrawPair:          "paired with %s: chance %d of %d by pairing weights, rolled %d",
+		WhySeed:              "Selection seed: %d",
+		WhyMode:              "Selection mode: %s",
+		WhyDrawStarter:       "starter %s: chance %d of %d, rolled %d",
+		WhyDrawSoup:          "soup %s: chance %d of %d, rolled %d",
+		FindUsage:            "Usage: /find chicken",
+		FindNothing:          "Nothing found for %q",
+		FindResults:          "Dishes found: %d. Pick one and I'll complete the meal",
+		FindGone:             "This dish is no longer in the catalogue",
+		InlineLimit:          "Request limit exceeded",
+		InlineNothing:        "No matching dinners",
+		ModeCurrent:          "Selection mode: %s",
+		ModeChanged:          "Selection mode: %s",
+		ModeUsage:            "/mode auto - default mode",
+		RateUsage:            "Usage: /rate <dish> <1-5>, /rate <dish> 0 - remove the rating",
+		RateSaved:            "Rating of %s: %d",
+		RateCleared:          "Rating of %s removed",
+		RateUnknownFood:      "Dish %q not found",
+		RateNone:             "You haven't rated any dishes yet",
+		RateList:             "Your ratings:",
+		"mode_random":        "a random dish",
+		"mode_rating":        "highly rated dishes more often (/rate)",
+		"mode_fresh":         "what hasn't been cooked for the longest first",
+		"mode_roundrobin":    "every dish of the catalogue in turn",
+		"mode_shuffle":       "every dish of the catalogue once, in random order",
+		"category_1":         "Soup",
+		"category_2":         "Salad",
+		"category_3":         "Meat",
+		"category_4":         "Side dish",
+	},
+}
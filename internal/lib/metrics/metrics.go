@@ -0,0 +1,63 @@
+// Пакет с метриками приложения в формате Prometheus
+package metrics
+
+import (
+	"dinner/internal/domain/models"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// Обработанные команды бота
+	CommandsHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "dinner_commands_handled_total",
+		Help: "Number of handled bot commands.",
+	}, []string{"command", "status"})
+	// Сформированные ужины по составу
+	DinnersGenerated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "dinner_dinners_generated_total",
+		Help: "Number of generated dinners by composition type.",
+	}, []string{"composition"})
+	// Отказы из-за превышения лимита запросов
+	QuotaRejections = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dinner_quota_rejections_total",
+		Help: "Number of requests rejected by the attempt limit.",
+	})
+	// Ошибки работы с БД
+	StorageErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "dinner_storage_errors_total",
+		Help: "Number of storage errors by operation.",
+	}, []string{"op"})
+	// Время отправки сообщений в Telegram
+	TelegramSendDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "dinner_telegram_send_duration_seconds",
+		Help:    "Latency of Telegram send requests.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+// Названия категорий для метки composition
+var compositionNames = map[models.FootCategory]string{
+	models.Soup:     "soup",
+	models.Salad:    "salad",
+	models.Meat:     "meat",
+	models.SideDish: "side_dish",
+}
+
+// Composition отдает тип состава ужина для метки, например "meat+side_dish".
+// Порядок блюд в ужине не влияет на результат.
+func Composition(foods []models.Food) string {
+	parts := make([]string, 0, len(foods))
+	for _, food := range foods {
+		name, ok := compositionNames[food.Category]
+		if !ok {
+			name = "other"
+		}
+		parts = append(parts, name)
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, "+")
+}
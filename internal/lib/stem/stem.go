@@ -0,0 +1,134 @@
+// Пакет для нестрогого сравнения русских слов.
+// Отбрасывает падежные окончания, чтобы "курицу", "курицей" и "курица"
+// считались одним продуктом.
+package stem
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Минимальная длина основы в буквах, короче окончание не отбрасывается
+const minStem = 3
+
+// Окончания от длинных к коротким
+var endings = []string{
+	"иями", "ями", "ами", "ией", "иях", "ого", "его", "ому", "ему",
+	"ях", "ах", "ов", "ев", "ей", "ий", "ый", "ой", "ая", "яя", "ое", "ее", "ые", "ие",
+	"ую", "юю", "ом", "ем", "ам", "ям", "ию", "ия", "ья", "ье", "ью",
+	"а", "я", "о", "е", "ы", "и", "у", "ю", "ь", "й",
+}
+
+// Разговорные названия продуктов и формы, которые не сводятся отбрасыванием окончания
+var synonyms = map[string]string{
+	"картошк": "картофел",
+	"яиц":     "яйц",
+}
+
+// Word отдает основу слова: нижний регистр, "ё" заменена на "е", окончание отброшено
+func Word(word string) string {
+	word = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(word)), "ё", "е")
+	base := word
+	for _, ending := range endings {
+		if b, ok := strings.CutSuffix(word, ending); ok && len([]rune(b)) >= minStem {
+			base = b
+			break
+		}
+	}
+	if synonym, ok := synonyms[base]; ok {
+		return synonym
+	}
+	return base
+}
+
+// Phrase отдает основы всех слов фразы
+func Phrase(phrase string) []string {
+	words := strings.FieldsFunc(phrase, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	res := make([]string, 0, len(words))
+	for _, word := range words {
+		res = append(res, Word(word))
+	}
+	return res
+}
+
+// Match сообщает, что продукт have подходит под ингредиент ingredient:
+// каждое слово have есть среди слов ingredient с точностью до окончания.
+// Продукт "огурцы" подходит под ингредиент "огурцы соленые",
+// а продукт "сыр фета" под ингредиент "сыр" - нет.
+func Match(have string, ingredient string) bool {
+	haveWords := Phrase(have)
+	if len(haveWords) == 0 {
+		return false
+	}
+	ingredientWords := Phrase(ingredient)
+	for _, h := range haveWords {
+		found := false
+		for _, i := range ingredientWords {
+			if same(h, i) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// same сравнивает основы. Длинные основы могут отличаться одной буквой,
+// что покрывает беглые гласные ("огурец" - "огурц").
+func same(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < 4 || len(rb) < 4 {
+		return false
+	}
+	return distance(ra, rb) <= 1
+}
+
+// distance - расстояние Левенштейна между a и b
+func distance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// Similar сравнивает основы при поиске с учетом опечаток: основа a подходит,
+// если она - начало основы b ("кур" - "куриц") или отличается от нее
+// не больше чем на одну букву (от 4 букв) или на две (от 7 букв)
+func Similar(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) >= minStem && strings.HasPrefix(b, a) {
+		return true
+	}
+	typos := 0
+	switch {
+	case len(ra) >= 7:
+		typos = 2
+	case len(ra) >= 4:
+		typos = 1
+	}
+	return typos > 0 && distance(ra, rb) <= typos
+}
@@ -0,0 +1,206 @@
+package restapi
+
+import (
+	"dinner/internal/domain/models"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Блюдо в ответах и запросах API
+type foodDTO struct {
+	ID          int64             `json:"id"`
+	Name        string            `json:"name"`
+	Category    int               `json:"category"`
+	Tags        []string          `json:"tags"`
+	Ingredients []string          `json:"ingredients"`
+	Names       map[string]string `json:"names,omitempty"`
+	Recipe      string            `json:"recipe,omitempty"`
+	Price       int               `json:"price,omitempty"`
+	Nutrition   *nutritionDTO     `json:"nutrition,omitempty"`
+	Seasons     []string          `json:"seasons,omitempty"`
+	Weekdays    []string          `json:"weekdays,omitempty"`
+}
+
+// Пищевая ценность порции или всего ужина
+type nutritionDTO struct {
+	Kcal    float64 `json:"kcal"`
+	Protein float64 `json:"protein"`
+	Fat     float64 `json:"fat"`
+	Carbs   float64 `json:"carbs"`
+}
+
+// Сочетание мяса и гарнира: 0 - запрещено, 1 - обычное, больше - чаще обычного
+type pairingDTO struct {
+	MeatID int64 `json:"meatId"`
+	SideID int64 `json:"sideId"`
+	Weight int   `json:"weight"`
+}
+
+// Категория блюд
+type categoryDTO struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Ужин: блюда и готовый текст для виджета.
+// Seed повторяет этот подбор через ?seed=.
+// У остатков ужина заполнен Until - до какого момента их предлагают.
+type dinnerDTO struct {
+	Foods     []foodDTO     `json:"foods"`
+	Text      string        `json:"text"`
+	Seed      uint64        `json:"seed,omitempty"`
+	Nutrition *nutritionDTO `json:"nutrition,omitempty"`
+	// Примерная стоимость порции в рублях, 0 - неизвестна
+	Cost      int        `json:"cost,omitempty"`
+	Leftovers bool       `json:"leftovers,omitempty"`
+	Until     *time.Time `json:"until,omitempty"`
+}
+
+// Запрос на сохранение остатков последнего ужина
+type leftoversDTO struct {
+	Days int `json:"days"`
+}
+
+// План ужинов по дням
+type planDTO struct {
+	Days []dinnerDTO `json:"days"`
+	Seed uint64      `json:"seed"`
+	// Сумма известных стоимостей ужинов плана
+	Cost int `json:"cost,omitempty"`
+}
+
+// Заготовка ужинов: ужины, порядок готовки и список покупок
+type mealPrepDTO struct {
+	Dinners   []dinnerDTO       `json:"dinners"`
+	Portions  int               `json:"portions"`
+	CookOrder []foodDTO         `json:"cookOrder"`
+	Shopping  []shoppingItemDTO `json:"shopping"`
+	Seed      uint64            `json:"seed"`
+}
+
+// Ингредиент в списке покупок
+type shoppingItemDTO struct {
+	Ingredient string `json:"ingredient"`
+	Dishes     int    `json:"dishes"`
+	Portions   int    `json:"portions"`
+}
+
+// Один запрос из истории
+type historyDTO struct {
+	Time  time.Time `json:"time"`
+	Foods []foodDTO `json:"foods"`
+}
+
+// Ошибка
+type errorDTO struct {
+	Error string `json:"error"`
+}
+
+func toFoodDTO(food models.Food) foodDTO {
+	tags := food.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	ingredients := food.Ingredients
+	if ingredients == nil {
+		ingredients = []string{}
+	}
+	return foodDTO{
+		ID:          food.ID,
+		Name:        food.Name,
+		Category:    int(food.Category),
+		Tags:        tags,
+		Ingredients: ingredients,
+		Names:       food.Names,
+		Recipe:      food.Recipe,
+		Price:       food.Price,
+		Nutrition:   toNutritionDTO(food.Nutrition),
+		Seasons:     models.SeasonNames(food.Seasons),
+		Weekdays:    models.WeekdayNames(food.Weekdays),
+	}
+}
+
+func toNutritionDTO(n *models.Nutrition) *nutritionDTO {
+	if n == nil {
+		return nil
+	}
+	return &nutritionDTO{Kcal: n.Kcal, Protein: n.Protein, Fat: n.Fat, Carbs: n.Carbs}
+}
+
+// toDinnerDTO отдает ужин с текстом и итоговой пищевой ценностью
+func toDinnerDTO(foods []models.Food, text string) dinnerDTO {
+	res := dinnerDTO{Foods: toFoodDTOs(foods), Text: text}
+	if total, ok := models.TotalNutrition(foods); ok {
+		res.Nutrition = toNutritionDTO(&total)
+	}
+	return res
+}
+
+// toPricedDinnerDTO отдает ужин как toDinnerDTO и его примерную стоимость по ценам prices
+func toPricedDinnerDTO(foods []models.Food, text string, prices models.Prices) dinnerDTO {
+	res := toDinnerDTO(foods, text)
+	if cost, ok := prices.DinnerCost(foods); ok {
+		res.Cost = cost
+	}
+	return res
+}
+
+// toLeftoversDTO отдает остатки ужина в виде ужина с признаком leftovers
+func toLeftoversDTO(leftover models.Leftover, text string) dinnerDTO {
+	res := toDinnerDTO(leftover.Foods, text)
+	res.Leftovers = true
+	res.Until = &leftover.Until
+	return res
+}
+
+func toFoodDTOs(foods []models.Food) []foodDTO {
+	res := make([]foodDTO, 0, len(foods))
+	for _, food := range foods {
+		res = append(res, toFoodDTO(food))
+	}
+	return res
+}
+
+// model переводит блюдо из запроса в модель, названия сезонов и дней недели проверяются
+func (f foodDTO) model() (models.Food, error) {
+	seasons, err := models.ParseSeasons(f.Seasons)
+	if err != nil {
+		return models.Food{}, err
+	}
+	weekdays, err := models.ParseWeekdays(f.Weekdays)
+	if err != nil {
+		return models.Food{}, err
+	}
+	return models.Food{
+		ID:          f.ID,
+		Name:        f.Name,
+		Category:    models.FootCategory(f.Category),
+		Tags:        f.Tags,
+		Ingredients: f.Ingredients,
+		Names:       f.Names,
+		Recipe:      f.Recipe,
+		Nutrition:   f.Nutrition.model(),
+		Seasons:     seasons,
+		Weekdays:    weekdays,
+	}, nil
+}
+
+func (n *nutritionDTO) model() *models.Nutrition {
+	if n == nil {
+		return nil
+	}
+	return &models.Nutrition{Kcal: n.Kcal, Protein: n.Protein, Fat: n.Fat, Carbs: n.Carbs}
+}
+
+// writeJSON отправляет v в ответ с кодом status
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError отправляет ошибку в виде {"error": message}
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorDTO{Error: message})
+}
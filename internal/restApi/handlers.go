@@ -0,0 +1,552 @@
+package restapi
+
+import (
+	"cmp"
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/metrics"
+	"dinner/internal/services"
+	adminservice "dinner/internal/services/admin"
+	dinnerservice "dinner/internal/services/dinner"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Количество дней в плане по умолчанию
+const defaultPlanDays = 7
+
+// getDinner отдает случайный ужин. Запрос учитывается в лимите так же, как /dinner в боте.
+// Цель по калориям пользователя учитывается, ?light=true подбирает легкий ужин,
+// ?full=true - ужин из нескольких подач (закуска, суп и основное блюдо),
+// ?cheap=true - дешевый ужин. Бюджет пользователя учитывается, в ответе примерная стоимость.
+// Сезон и день недели берутся по текущей дате в часовом поясе пользователя.
+// Для участника семьи лимит, история и остатки общие, учитываются ограничения в питании всей семьи.
+// Пока есть остатки ужина, отдаются они без учета в лимите, ?new=true подбирает новый ужин.
+// ?food=<id> составляет ужин вокруг выбранного блюда (см. getDinnerWith).
+func (a *RestAPI) getDinner(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.getDinner"
+	log := a.log.With(slog.String("op", op))
+
+	if value := r.URL.Query().Get("food"); value != "" {
+		a.getDinnerWith(w, r, userId, value)
+		return
+	}
+
+	fresh, err := strconv.ParseBool(cmp.Or(r.URL.Query().Get("new"), "false"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "new must be true or false")
+		return
+	}
+	if !fresh {
+		leftover, ok, err := a.leftovers.Current(a.households.Account(userId), a.prefs.Now(userId))
+		if err != nil {
+			a.serviceError(w, log, err)
+			return
+		}
+		if ok {
+			lang := a.prefs.Language(userId, r.Header.Get("Accept-Language"))
+			writeJSON(w, http.StatusOK, toLeftoversDTO(leftover, a.formatter.Dinner(lang, leftover.Foods)))
+			return
+		}
+	}
+	seed, ok := requestSeed(w, r, a.dinner.NextSeed)
+	if !ok {
+		return
+	}
+	light, err := strconv.ParseBool(cmp.Or(r.URL.Query().Get("light"), "false"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "light must be true or false")
+		return
+	}
+	full, err := strconv.ParseBool(cmp.Or(r.URL.Query().Get("full"), "false"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "full must be true or false")
+		return
+	}
+	cheap, err := strconv.ParseBool(cmp.Or(r.URL.Query().Get("cheap"), "false"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "cheap must be true or false")
+		return
+	}
+	exclude, err := a.households.CombinedRestrictions(userId)
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	prices, err := a.catalog.Prices()
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	opts := dinnerservice.Options{
+		Kcal:    a.prefs.KcalTarget(userId),
+		Light:   light,
+		Full:    full,
+		Date:    a.prefs.Now(userId),
+		Exclude: exclude,
+		Mode:    cmp.Or(r.URL.Query().Get("mode"), a.prefs.Mode(userId)),
+		Budget:  a.prefs.Budget(a.households.Account(userId)),
+		Cheap:   cheap,
+		Prices:  prices,
+	}
+	foods, err := a.dinner.WithSeed(seed).GetDinner(a.households.Account(userId), opts)
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	metrics.DinnersGenerated.WithLabelValues(metrics.Composition(foods)).Inc()
+	lang := a.prefs.Language(userId, r.Header.Get("Accept-Language"))
+	res := toPricedDinnerDTO(foods, a.formatter.Dinner(lang, foods), prices)
+	res.Seed = seed
+	writeJSON(w, http.StatusOK, res)
+}
+
+// getDinnerWith составляет ужин вокруг блюда с id value: к мясу подбирается гарнир,
+// к гарниру - мясо. Запрос учитывается в лимите.
+func (a *RestAPI) getDinnerWith(w http.ResponseWriter, r *http.Request, userId int64, value string) {
+	const op = "RestAPI.getDinnerWith"
+	log := a.log.With(slog.String("op", op))
+
+	foodId, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "food must be a number")
+		return
+	}
+	seed, ok := requestSeed(w, r, a.dinner.NextSeed)
+	if !ok {
+		return
+	}
+	exclude, err := a.households.CombinedRestrictions(userId)
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	opts := dinnerservice.Options{Date: a.prefs.Now(userId), Exclude: exclude}
+	foods, _, err := a.dinner.WithSeed(seed).GetDinnerWith(a.households.Account(userId), foodId, opts)
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	metrics.DinnersGenerated.WithLabelValues(metrics.Composition(foods)).Inc()
+	lang := a.prefs.Language(userId, r.Header.Get("Accept-Language"))
+	res := toDinnerDTO(foods, a.formatter.Dinner(lang, foods))
+	res.Seed = seed
+	writeJSON(w, http.StatusOK, res)
+}
+
+// getPlan отдает план ужинов на ?days= дней (по умолчанию на неделю) начиная с сегодняшнего
+func (a *RestAPI) getPlan(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.getPlan"
+	log := a.log.With(slog.String("op", op))
+
+	days := defaultPlanDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		var err error
+		days, err = strconv.Atoi(value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "days must be a number")
+			return
+		}
+	}
+	seed, ok := requestSeed(w, r, a.dinner.NextSeed)
+	if !ok {
+		return
+	}
+	exclude, err := a.households.CombinedRestrictions(userId)
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	prices, err := a.catalog.Prices()
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	opts := dinnerservice.Options{
+		Date:    a.prefs.Now(userId),
+		Exclude: exclude,
+		Mode:    cmp.Or(r.URL.Query().Get("mode"), a.prefs.Mode(userId)),
+		Budget:  a.prefs.Budget(a.households.Account(userId)),
+		Prices:  prices,
+	}
+	plan, err := a.dinner.WithSeed(seed).GetPlan(a.households.Account(userId), days, opts)
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	lang := a.prefs.Language(userId, r.Header.Get("Accept-Language"))
+	res := planDTO{Days: make([]dinnerDTO, 0, len(plan)), Seed: seed}
+	for _, foods := range plan {
+		metrics.DinnersGenerated.WithLabelValues(metrics.Composition(foods)).Inc()
+		day := toPricedDinnerDTO(foods, a.formatter.Dinner(lang, foods), prices)
+		res.Cost += day.Cost
+		res.Days = append(res.Days, day)
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// getMealPrep отдает заготовку ужинов на неделю с общими ингредиентами:
+// ?dinners= ужинов по ?portions= порций
+func (a *RestAPI) getMealPrep(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.getMealPrep"
+	log := a.log.With(slog.String("op", op))
+
+	dinners, portions := dinnerservice.DefaultPrepDinners, dinnerservice.DefaultPrepPortions
+	for name, value := range map[string]*int{"dinners": &dinners, "portions": &portions} {
+		if param := r.URL.Query().Get(name); param != "" {
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, name+" must be a number")
+				return
+			}
+			*value = n
+		}
+	}
+	seed, ok := requestSeed(w, r, a.dinner.NextSeed)
+	if !ok {
+		return
+	}
+	exclude, err := a.households.CombinedRestrictions(userId)
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	opts := dinnerservice.Options{
+		Date:    a.prefs.Now(userId),
+		Exclude: exclude,
+		Mode:    cmp.Or(r.URL.Query().Get("mode"), a.prefs.Mode(userId)),
+	}
+	prep, err := a.dinner.WithSeed(seed).GetMealPrep(a.households.Account(userId), dinners, portions, opts)
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	lang := a.prefs.Language(userId, r.Header.Get("Accept-Language"))
+	res := mealPrepDTO{
+		Dinners:   make([]dinnerDTO, 0, len(prep.Dinners)),
+		Portions:  prep.Portions,
+		CookOrder: toFoodDTOs(prep.CookOrder),
+		Shopping:  make([]shoppingItemDTO, 0, len(prep.Shopping)),
+		Seed:      seed,
+	}
+	for _, foods := range prep.Dinners {
+		metrics.DinnersGenerated.WithLabelValues(metrics.Composition(foods)).Inc()
+		res.Dinners = append(res.Dinners, toDinnerDTO(foods, a.formatter.Dinner(lang, foods)))
+	}
+	for _, item := range prep.Shopping {
+		res.Shopping = append(res.Shopping, shoppingItemDTO{Ingredient: item.Ingredient, Dishes: item.Dishes, Portions: item.Portions})
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// getLeftovers отдает остатки ужина пользователя, 404 - если их нет
+func (a *RestAPI) getLeftovers(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.getLeftovers"
+	log := a.log.With(slog.String("op", op))
+
+	leftover, ok, err := a.leftovers.Current(a.households.Account(userId), a.prefs.Now(userId))
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "no leftovers")
+		return
+	}
+	lang := a.prefs.Language(userId, r.Header.Get("Accept-Language"))
+	writeJSON(w, http.StatusOK, toLeftoversDTO(leftover, a.formatter.Dinner(lang, leftover.Foods)))
+}
+
+// keepLeftovers отмечает последний ужин пользователя как остатки на days дней
+func (a *RestAPI) keepLeftovers(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.keepLeftovers"
+	log := a.log.With(slog.String("op", op))
+
+	var body leftoversDTO
+	if !readJSON(w, r, &body) {
+		return
+	}
+	leftover, err := a.leftovers.Keep(a.households.Account(userId), body.Days, a.prefs.Now(userId))
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	lang := a.prefs.Language(userId, r.Header.Get("Accept-Language"))
+	writeJSON(w, http.StatusOK, toLeftoversDTO(leftover, a.formatter.Dinner(lang, leftover.Foods)))
+}
+
+// clearLeftovers удаляет остатки ужина пользователя
+func (a *RestAPI) clearLeftovers(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.clearLeftovers"
+	log := a.log.With(slog.String("op", op))
+
+	if err := a.leftovers.Clear(a.households.Account(userId)); err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getHistory отдает историю запросов пользователя или его семьи
+func (a *RestAPI) getHistory(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.getHistory"
+	log := a.log.With(slog.String("op", op))
+
+	history, err := a.stats.GetHistory(a.households.Account(userId))
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	res := make([]historyDTO, 0, len(history))
+	for _, entry := range history {
+		res = append(res, historyDTO{Time: entry.Time, Foods: toFoodDTOs(entry.Foods)})
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// getCategories отдает категории блюд
+func (a *RestAPI) getCategories(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.getCategories"
+	log := a.log.With(slog.String("op", op))
+
+	categories, err := a.catalog.Categories()
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	res := make([]categoryDTO, 0, len(categories))
+	for _, category := range categories {
+		res = append(res, categoryDTO{ID: int(category.ID), Name: category.Name})
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// getFoods отдает весь каталог блюд, с ?q= - найденные блюда от лучшего совпадения
+func (a *RestAPI) getFoods(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.getFoods"
+	log := a.log.With(slog.String("op", op))
+
+	var foods []models.Food
+	var err error
+	if query := r.URL.Query().Get("q"); query != "" {
+		foods, err = a.catalog.Search(query, 0)
+	} else {
+		foods, err = a.catalog.Foods()
+	}
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toFoodDTOs(foods))
+}
+
+// getFood отдает блюдо по id
+func (a *RestAPI) getFood(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.getFood"
+	log := a.log.With(slog.String("op", op))
+
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	food, err := a.catalog.Food(id)
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toFoodDTO(food))
+}
+
+// createFood добавляет блюдо в каталог (для администраторов)
+func (a *RestAPI) createFood(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.createFood"
+	log := a.log.With(slog.String("op", op))
+
+	var body foodDTO
+	if !readJSON(w, r, &body) {
+		return
+	}
+	food, err := body.model()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := a.admin.Audit(userId, adminservice.ActionFoodCreate, food.Name); err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	food, err = a.catalog.CreateFood(food)
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	log.Info("food created", slog.Int64("user", userId), slog.Int64("id", food.ID))
+	writeJSON(w, http.StatusCreated, toFoodDTO(food))
+}
+
+// updateFood изменяет блюдо id (для администраторов)
+func (a *RestAPI) updateFood(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.updateFood"
+	log := a.log.With(slog.String("op", op))
+
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var body foodDTO
+	if !readJSON(w, r, &body) {
+		return
+	}
+	body.ID = id
+	food, err := body.model()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := a.admin.Audit(userId, adminservice.ActionFoodUpdate, strconv.FormatInt(id, 10)+" "+food.Name); err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	food, err = a.catalog.UpdateFood(food)
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	log.Info("food updated", slog.Int64("user", userId), slog.Int64("id", id))
+	writeJSON(w, http.StatusOK, toFoodDTO(food))
+}
+
+// deleteFood удаляет блюдо id из каталога (для администраторов)
+func (a *RestAPI) deleteFood(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.deleteFood"
+	log := a.log.With(slog.String("op", op))
+
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	if err := a.admin.Audit(userId, adminservice.ActionFoodDelete, strconv.FormatInt(id, 10)); err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	if err := a.catalog.DeleteFood(id); err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	log.Info("food deleted", slog.Int64("user", userId), slog.Int64("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getPairings отдает сочетания мяса и гарниров с необычным весом
+func (a *RestAPI) getPairings(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.getPairings"
+	log := a.log.With(slog.String("op", op))
+
+	pairings, err := a.catalog.Pairings()
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	res := make([]pairingDTO, 0, len(pairings))
+	for _, p := range pairings {
+		res = append(res, pairingDTO{MeatID: p.MeatID, SideID: p.SideID, Weight: p.Weight})
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// setPairing сохраняет вес сочетания мяса и гарнира (для администраторов)
+func (a *RestAPI) setPairing(w http.ResponseWriter, r *http.Request, userId int64) {
+	const op = "RestAPI.setPairing"
+	log := a.log.With(slog.String("op", op))
+
+	var body pairingDTO
+	if !readJSON(w, r, &body) {
+		return
+	}
+	args := fmt.Sprintf("%d %d %d", body.MeatID, body.SideID, body.Weight)
+	if err := a.admin.Audit(userId, adminservice.ActionPairing, args); err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	pairing, err := a.catalog.SetPairing(models.Pairing{MeatID: body.MeatID, SideID: body.SideID, Weight: body.Weight})
+	if err != nil {
+		a.serviceError(w, log, err)
+		return
+	}
+	log.Info("pairing updated", slog.Int64("user", userId), slog.Int64("meat", pairing.MeatID), slog.Int64("side", pairing.SideID))
+	writeJSON(w, http.StatusOK, pairingDTO{MeatID: pairing.MeatID, SideID: pairing.SideID, Weight: pairing.Weight})
+}
+
+// serviceError переводит ошибку сервиса в код ответа
+func (a *RestAPI) serviceError(w http.ResponseWriter, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, services.ErrAttemptLimitExceeded):
+		metrics.QuotaRejections.Inc()
+		writeError(w, http.StatusTooManyRequests, services.ErrAttemptLimitExceeded.Error())
+	case errors.Is(err, services.ErrInvalidPlanDays):
+		writeError(w, http.StatusBadRequest, services.ErrInvalidPlanDays.Error())
+	case errors.Is(err, services.ErrInvalidMealPrep):
+		writeError(w, http.StatusBadRequest, services.ErrInvalidMealPrep.Error())
+	case errors.Is(err, services.ErrUnknownMode):
+		writeError(w, http.StatusBadRequest, services.ErrUnknownMode.Error())
+	case errors.Is(err, services.ErrInvalidCatalog):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrInvalidLeftoverDays):
+		writeError(w, http.StatusBadRequest, services.ErrInvalidLeftoverDays.Error())
+	case errors.Is(err, services.ErrNoDinnerToKeep):
+		writeError(w, http.StatusConflict, services.ErrNoDinnerToKeep.Error())
+	case errors.Is(err, services.ErrInvalidPairing):
+		writeError(w, http.StatusBadRequest, services.ErrInvalidPairing.Error())
+	case errors.Is(err, services.ErrFoodNotFound):
+		writeError(w, http.StatusNotFound, services.ErrFoodNotFound.Error())
+	case errors.Is(err, services.ErrFoodExists):
+		writeError(w, http.StatusConflict, services.ErrFoodExists.Error())
+	case errors.Is(err, services.ErrNoMatchingDinner):
+		writeError(w, http.StatusNotFound, services.ErrNoMatchingDinner.Error())
+	case errors.Is(err, services.ErrEmptyFood):
+		writeError(w, http.StatusServiceUnavailable, services.ErrEmptyFood.Error())
+	case errors.Is(err, services.ErrAccessDenied):
+		writeError(w, http.StatusForbidden, "access denied")
+	default:
+		log.Error("request error", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "internal error")
+	}
+}
+
+// requestSeed читает зерно подбора из ?seed=, без него берет next()
+func requestSeed(w http.ResponseWriter, r *http.Request, next func() uint64) (uint64, bool) {
+	value := r.URL.Query().Get("seed")
+	if value == "" {
+		return next(), true
+	}
+	seed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || seed == 0 {
+		writeError(w, http.StatusBadRequest, "seed must be a positive number")
+		return 0, false
+	}
+	return seed, true
+}
+
+// pathID читает id блюда из пути запроса
+func pathID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id < 1 {
+		writeError(w, http.StatusBadRequest, "invalid food id")
+		return 0, false
+	}
+	return id, true
+}
+
+// readJSON читает тело запроса в v
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return false
+	}
+	return true
+}
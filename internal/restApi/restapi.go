@@ -0,0 +1,194 @@
+// Пакет HTTP JSON API сервиса ужинов.
+// Описание API лежит в openapi.yaml и отдается по адресу /api/openapi.yaml.
+package restapi
+
+import (
+	"context"
+	"dinner/internal/lib/formatter"
+	"dinner/internal/lib/metrics"
+	adminservice "dinner/internal/services/admin"
+	catalogservice "dinner/internal/services/catalog"
+	dinnerservice "dinner/internal/services/dinner"
+	householdservice "dinner/internal/services/household"
+	leftoversservice "dinner/internal/services/leftovers"
+	preferencesservice "dinner/internal/services/preferences"
+	statsservice "dinner/internal/services/stats"
+	_ "embed"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Заголовок с ключом API
+const APIKeyHeader = "X-API-Key"
+
+// Таймаут чтения заголовков запроса
+const readHeaderTimeout = 5 * time.Second
+
+// Максимальный размер тела запроса
+const maxBodySize = 1 << 16
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+type RestAPI struct {
+	log     *slog.Logger
+	keys    map[string]int64
+	dinner  *dinnerservice.Dinner
+	catalog *catalogservice.Catalog
+	stats   *statsservice.Stats
+	admin   *adminservice.Admin
+	// Настройки пользователей: язык, калории, бюджет, часовой пояс, режим
+	prefs *preferencesservice.Preferences
+	// Остатки ужинов, которые доедают несколько дней
+	leftovers *leftoversservice.Leftovers
+	// Семьи: запросы участника выполняются от имени семьи
+	households *householdservice.Households
+	// Текст ужина для виджетов без разметки
+	formatter *formatter.Formatter
+	server    *http.Server
+}
+
+// Обработчик запроса от пользователя userId
+type userHandler func(w http.ResponseWriter, r *http.Request, userId int64)
+
+// New Конструктор API
+// log *slog.Logger - логгер
+// address string - адрес, на котором слушает сервер
+// keys map[string]int64 - ключи API и id пользователей, от имени которых выполняются запросы
+// dinner *dinnerservice.Dinner - сервис, который генерит что приготовить на ужин
+// catalog *catalogservice.Catalog - сервис каталога блюд
+// stats *statsservice.Stats - сервис истории и статистики
+// admin *adminservice.Admin - сервис пользователей, проверка прав администратора
+// prefs *preferencesservice.Preferences - сервис настроек пользователей
+// leftovers *leftoversservice.Leftovers - сервис остатков ужинов
+// households *householdservice.Households - сервис семей
+func New(
+	log *slog.Logger,
+	address string,
+	keys map[string]int64,
+	dinner *dinnerservice.Dinner,
+	catalog *catalogservice.Catalog,
+	stats *statsservice.Stats,
+	admin *adminservice.Admin,
+	prefs *preferencesservice.Preferences,
+	leftovers *leftoversservice.Leftovers,
+	households *householdservice.Households,
+) *RestAPI {
+	a := &RestAPI{
+		log:        log,
+		keys:       keys,
+		dinner:     dinner,
+		catalog:    catalog,
+		stats:      stats,
+		admin:      admin,
+		prefs:      prefs,
+		leftovers:  leftovers,
+		households: households,
+		formatter:  formatter.New(formatter.Plain),
+	}
+	a.server = &http.Server{
+		Addr:              address,
+		Handler:           a.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	return a
+}
+
+// Handler отдает обработчик всех маршрутов API
+func (a *RestAPI) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(openAPISpec)
+	})
+	mux.Handle("GET /api/v1/dinner", a.auth("dinner", a.getDinner))
+	mux.Handle("GET /api/v1/plan", a.auth("plan", a.getPlan))
+	mux.Handle("GET /api/v1/prep", a.auth("prep", a.getMealPrep))
+	mux.Handle("GET /api/v1/leftovers", a.auth("leftovers", a.getLeftovers))
+	mux.Handle("POST /api/v1/leftovers", a.auth("leftovers", a.keepLeftovers))
+	mux.Handle("DELETE /api/v1/leftovers", a.auth("leftovers", a.clearLeftovers))
+	mux.Handle("GET /api/v1/history", a.auth("history", a.getHistory))
+	mux.Handle("GET /api/v1/categories", a.auth("categories", a.getCategories))
+	mux.Handle("GET /api/v1/foods", a.auth("foods", a.getFoods))
+	mux.Handle("POST /api/v1/foods", a.auth("foods", a.adminOnly(a.createFood)))
+	mux.Handle("GET /api/v1/foods/{id}", a.auth("food", a.getFood))
+	mux.Handle("PUT /api/v1/foods/{id}", a.auth("food", a.adminOnly(a.updateFood)))
+	mux.Handle("DELETE /api/v1/foods/{id}", a.auth("food", a.adminOnly(a.deleteFood)))
+	mux.Handle("GET /api/v1/pairings", a.auth("pairings", a.getPairings))
+	mux.Handle("PUT /api/v1/pairings", a.auth("pairings", a.adminOnly(a.setPairing)))
+	return mux
+}
+
+// Run запускает сервер и блокируется до его остановки
+func (a *RestAPI) Run() {
+	const op = "RestAPI.Run"
+	log := a.log.With(slog.String("op", op))
+
+	log.Info("rest api started", slog.String("address", a.server.Addr))
+	if err := a.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		panic(err)
+	}
+}
+
+// Stop останавливает сервер, дожидаясь завершения текущих запросов
+func (a *RestAPI) Stop(ctx context.Context) error {
+	return a.server.Shutdown(ctx)
+}
+
+// auth находит пользователя по ключу API и учитывает запрос в метриках.
+// Заблокированные пользователи получают 403, как и в боте.
+func (a *RestAPI) auth(name string, next userHandler) http.Handler {
+	command := "api_" + name
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(APIKeyHeader)
+		userId, ok := a.keys[key]
+		if !ok || key == "" {
+			metrics.CommandsHandled.WithLabelValues(command, "error").Inc()
+			writeError(w, http.StatusUnauthorized, "invalid api key")
+			return
+		}
+		banned, err := a.admin.IsBanned(userId)
+		if err != nil {
+			a.log.Error("check ban error", slog.Any("error", err))
+			metrics.CommandsHandled.WithLabelValues(command, "error").Inc()
+			writeError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+		if banned {
+			metrics.CommandsHandled.WithLabelValues(command, "error").Inc()
+			writeError(w, http.StatusForbidden, "user is banned")
+			return
+		}
+		rw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next(rw, r, userId)
+		status := "ok"
+		if rw.status >= http.StatusBadRequest {
+			status = "error"
+		}
+		metrics.CommandsHandled.WithLabelValues(command, status).Inc()
+	})
+}
+
+// adminOnly пропускает к обработчику только администраторов
+func (a *RestAPI) adminOnly(next userHandler) userHandler {
+	return func(w http.ResponseWriter, r *http.Request, userId int64) {
+		if !a.admin.IsAdmin(userId) {
+			writeError(w, http.StatusForbidden, "access denied")
+			return
+		}
+		next(w, r, userId)
+	}
+}
+
+// statusWriter запоминает код ответа для метрик
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,200 @@
+// Сервис пользователей бота и команд администраторов.
+// Все действия администраторов записываются в журнал.
+package adminservice
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	"dinner/internal/storages"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Период, за который пользователь считается активным
+const activePeriod = 7 * 24 * time.Hour
+
+// Действия администраторов в журнале
+const (
+	ActionBroadcast     = "broadcast"
+	ActionUsers         = "users"
+	ActionResetLimit    = "resetlimit"
+	ActionBan           = "ban"
+	ActionUnban         = "unban"
+	ActionCatalogExport = "catalog_export"
+	ActionCatalogImport = "catalog_import"
+	ActionPairing       = "pairing"
+	ActionFoodPhoto     = "food_photo"
+	ActionPrice         = "price"
+	ActionFoodCreate    = "food_create"
+	ActionFoodUpdate    = "food_update"
+	ActionFoodDelete    = "food_delete"
+)
+
+type Admin struct {
+	log          *slog.Logger
+	admins       []int64
+	userProvider UserProvider
+}
+
+// Доступ к пользователям бота и журналу действий администраторов
+type UserProvider interface {
+	SaveUser(user models.User) error
+	IsBanned(userId int64) (bool, error)
+	SetBanned(userId int64, banned bool) error
+	ResetLimit(userId int64, t time.Time) error
+	GetUsers() ([]models.User, error)
+	SaveAudit(entry models.AuditEntry) error
+}
+
+// New - конструктор сервиса
+// admins - id пользователей с правами администратора
+func New(log *slog.Logger, admins []int64, userProvider UserProvider) *Admin {
+	return &Admin{
+		log:          log,
+		admins:       admins,
+		userProvider: userProvider,
+	}
+}
+
+// IsAdmin проверяет, что пользователь userId является администратором
+func (a *Admin) IsAdmin(userId int64) bool {
+	return slices.Contains(a.admins, userId)
+}
+
+// Register запоминает пользователя и отдает признак его блокировки
+func (a *Admin) Register(user models.User) (bool, error) {
+	const op = "Admin.Register"
+
+	if err := a.userProvider.SaveUser(user); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	banned, err := a.userProvider.IsBanned(user.ID)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return banned, nil
+}
+
+// IsBanned сообщает, заблокирован ли пользователь userId
+func (a *Admin) IsBanned(userId int64) (bool, error) {
+	const op = "Admin.IsBanned"
+
+	banned, err := a.userProvider.IsBanned(userId)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return banned, nil
+}
+
+// Audit записывает действие action администратора adminId в журнал,
+// предварительно проверив его права
+func (a *Admin) Audit(adminId int64, action string, args string) error {
+	const op = "Admin.Audit"
+
+	if !a.IsAdmin(adminId) {
+		return fmt.Errorf("%s: %w", op, services.ErrAccessDenied)
+	}
+	err := a.userProvider.SaveAudit(models.AuditEntry{
+		AdminID: adminId,
+		Action:  action,
+		Args:    args,
+		Time:    time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	a.log.Info("admin action",
+		slog.Int64("admin", adminId),
+		slog.String("action", action),
+		slog.String("args", args),
+	)
+	return nil
+}
+
+// CountUsers отдает количество пользователей бота
+func (a *Admin) CountUsers(adminId int64) (models.UsersCount, error) {
+	const op = "Admin.CountUsers"
+
+	count := models.UsersCount{}
+	if err := a.Audit(adminId, ActionUsers, ""); err != nil {
+		return count, fmt.Errorf("%s: %w", op, err)
+	}
+	users, err := a.userProvider.GetUsers()
+	if err != nil {
+		return count, fmt.Errorf("%s: %w", op, err)
+	}
+	since := time.Now().Add(-activePeriod)
+	for _, user := range users {
+		count.Total++
+		if user.Banned {
+			count.Banned++
+		} else if user.LastSeen.After(since) {
+			count.Active++
+		}
+	}
+	return count, nil
+}
+
+// BroadcastTargets отдает чаты всех незаблокированных пользователей для рассылки text
+func (a *Admin) BroadcastTargets(adminId int64, text string) ([]int64, error) {
+	const op = "Admin.BroadcastTargets"
+
+	if err := a.Audit(adminId, ActionBroadcast, text); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	users, err := a.userProvider.GetUsers()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	chats := make([]int64, 0, len(users))
+	for _, user := range users {
+		if !user.Banned {
+			chats = append(chats, user.ChatID)
+		}
+	}
+	return chats, nil
+}
+
+// ResetLimit сбрасывает лимит запросов пользователя userId
+func (a *Admin) ResetLimit(adminId int64, userId int64) error {
+	const op = "Admin.ResetLimit"
+
+	if err := a.Audit(adminId, ActionResetLimit, strconv.FormatInt(userId, 10)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := a.userProvider.ResetLimit(userId, time.Now()); err != nil {
+		if errors.Is(err, storages.ErrUserNotFound) {
+			return fmt.Errorf("%s: %w", op, services.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// SetBanned блокирует (banned == true) или разблокирует пользователя userId.
+// Заблокированных пользователей бот игнорирует.
+func (a *Admin) SetBanned(adminId int64, userId int64, banned bool) error {
+	const op = "Admin.SetBanned"
+
+	action := ActionUnban
+	if banned {
+		action = ActionBan
+	}
+	if err := a.Audit(adminId, action, strconv.FormatInt(userId, 10)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if banned && a.IsAdmin(userId) {
+		return fmt.Errorf("%s: %w", op, services.ErrAccessDenied)
+	}
+	if err := a.userProvider.SetBanned(userId, banned); err != nil {
+		if errors.Is(err, storages.ErrUserNotFound) {
+			return fmt.Errorf("%s: %w", op, services.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
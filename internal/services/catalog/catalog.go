@@ -0,0 +1,596 @@
+// Сервис импорта и экспорта каталога блюд в форматах CSV, JSON и YAML.
+package catalogservice
+
+import (
+	"cmp"
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	"dinner/internal/storages"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Наибольшая цена порции блюда или ингредиента в рублях
+const MaxPrice = 100000
+
+type Catalog struct {
+	log     *slog.Logger
+	storage CatalogStorage
+}
+
+// Доступ к каталогу блюд
+type CatalogStorage interface {
+	GetFoods() ([]models.Food, error)
+	GetCategories() ([]models.Category, error)
+	SaveFoods(foods []models.Food) error
+	CreateFood(food models.Food) (int64, error)
+	UpdateFood(food models.Food) error
+	DeleteFood(id int64) error
+	SetFoodPhoto(id int64, photo string) error
+	SetFoodPrice(id int64, price int) error
+	GetIngredientPrices() (models.Prices, error)
+	SetIngredientPrice(ingredient string, price int) error
+	GetPairings() ([]models.Pairing, error)
+	SetPairing(pairing models.Pairing) error
+}
+
+// New - конструктор сервиса
+func New(log *slog.Logger, storage CatalogStorage) *Catalog {
+	return &Catalog{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// Export выгружает весь каталог блюд в w в формате format
+func (c *Catalog) Export(format Format, w io.Writer) error {
+	const op = "Catalog.Export"
+
+	foods, err := c.storage.GetFoods()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	categories, err := c.storage.GetCategories()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	names := make(map[models.FootCategory]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+
+	records := make([]record, 0, len(foods))
+	for _, food := range foods {
+		records = append(records, record{
+			Name:        food.Name,
+			Category:    names[food.Category],
+			Tags:        food.Tags,
+			Ingredients: food.Ingredients,
+			Names:       food.Names,
+			Recipe:      food.Recipe,
+			Nutrition:   toNutritionRecord(food.Nutrition),
+			Seasons:     models.SeasonNames(food.Seasons),
+			Weekdays:    models.WeekdayNames(food.Weekdays),
+		})
+	}
+	if err := encode(format, w, records); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Import читает блюда из r в формате format, проверяет их и сравнивает с каталогом в БД.
+// Если dryRun == false, новые и измененные блюда сохраняются.
+// Блюда, которых нет в файле, не удаляются.
+func (c *Catalog) Import(format Format, r io.Reader, dryRun bool) (models.CatalogDiff, error) {
+	const op = "Catalog.Import"
+
+	log := c.log.With(
+		slog.String("op", op),
+	)
+	diff := models.CatalogDiff{}
+
+	records, err := decode(format, r)
+	if err != nil {
+		return diff, fmt.Errorf("%s: %w: %w", op, services.ErrInvalidCatalog, err)
+	}
+	categories, err := c.storage.GetCategories()
+	if err != nil {
+		return diff, fmt.Errorf("%s: %w", op, err)
+	}
+	foods, err := validate(records, categories)
+	if err != nil {
+		return diff, fmt.Errorf("%s: %w", op, err)
+	}
+
+	current, err := c.storage.GetFoods()
+	if err != nil {
+		return diff, fmt.Errorf("%s: %w", op, err)
+	}
+	diff = compare(current, foods)
+
+	if dryRun || (len(diff.Added) == 0 && len(diff.Updated) == 0) {
+		return diff, nil
+	}
+	changed := make([]models.Food, 0, len(diff.Added)+len(diff.Updated))
+	changed = append(changed, diff.Added...)
+	for _, update := range diff.Updated {
+		changed = append(changed, update.New)
+	}
+	if err := c.storage.SaveFoods(changed); err != nil {
+		return diff, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("catalog imported",
+		slog.Int("added", len(diff.Added)),
+		slog.Int("updated", len(diff.Updated)),
+	)
+	return diff, nil
+}
+
+// Foods отдает все блюда каталога
+func (c *Catalog) Foods() ([]models.Food, error) {
+	const op = "Catalog.Foods"
+
+	foods, err := c.storage.GetFoods()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return foods, nil
+}
+
+// Food отдает блюдо по id
+func (c *Catalog) Food(id int64) (models.Food, error) {
+	const op = "Catalog.Food"
+
+	foods, err := c.storage.GetFoods()
+	if err != nil {
+		return models.Food{}, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, food := range foods {
+		if food.ID == id {
+			return food, nil
+		}
+	}
+	return models.Food{}, fmt.Errorf("%s: %w", op, services.ErrFoodNotFound)
+}
+
+// Categories отдает категории блюд
+func (c *Catalog) Categories() ([]models.Category, error) {
+	const op = "Catalog.Categories"
+
+	categories, err := c.storage.GetCategories()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return categories, nil
+}
+
+// CreateFood проверяет и добавляет блюдо в каталог
+func (c *Catalog) CreateFood(food models.Food) (models.Food, error) {
+	const op = "Catalog.CreateFood"
+
+	food, err := c.checkFood(food)
+	if err != nil {
+		return food, fmt.Errorf("%s: %w", op, err)
+	}
+	food.ID, err = c.storage.CreateFood(food)
+	if err != nil {
+		return food, fmt.Errorf("%s: %w", op, mapStorageError(err))
+	}
+	return food, nil
+}
+
+// UpdateFood проверяет и обновляет блюдо food.ID
+func (c *Catalog) UpdateFood(food models.Food) (models.Food, error) {
+	const op = "Catalog.UpdateFood"
+
+	food, err := c.checkFood(food)
+	if err != nil {
+		return food, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := c.storage.UpdateFood(food); err != nil {
+		return food, fmt.Errorf("%s: %w", op, mapStorageError(err))
+	}
+	return food, nil
+}
+
+// DeleteFood удаляет блюдо id из каталога
+func (c *Catalog) DeleteFood(id int64) error {
+	const op = "Catalog.DeleteFood"
+
+	if err := c.storage.DeleteFood(id); err != nil {
+		return fmt.Errorf("%s: %w", op, mapStorageError(err))
+	}
+	return nil
+}
+
+// SetPhoto сохраняет фото блюда id (file_id в Telegram), пустой photo - удалить фото
+func (c *Catalog) SetPhoto(id int64, photo string) error {
+	const op = "Catalog.SetPhoto"
+
+	if err := c.storage.SetFoodPhoto(id, photo); err != nil {
+		return fmt.Errorf("%s: %w", op, mapStorageError(err))
+	}
+	c.log.Info("food photo saved", slog.Int64("food", id), slog.Bool("removed", photo == ""))
+	return nil
+}
+
+// Prices отдает примерные цены ингредиентов на порцию
+func (c *Catalog) Prices() (models.Prices, error) {
+	const op = "Catalog.Prices"
+
+	prices, err := c.storage.GetIngredientPrices()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return prices, nil
+}
+
+// SetPrice сохраняет примерную цену порции блюда или ингредиента name в рублях.
+// Сначала name ищется среди названий блюд, затем среди ингредиентов каталога.
+// Нулевая цена удаляет цену. Отдает блюдо, если цена назначена блюду.
+func (c *Catalog) SetPrice(name string, price int) (models.Food, bool, error) {
+	const op = "Catalog.SetPrice"
+
+	if price < 0 || price > MaxPrice {
+		return models.Food{}, false, fmt.Errorf("%s: %w: %d", op, services.ErrInvalidPrice, price)
+	}
+	food, err := c.FoodByName(name)
+	if err == nil {
+		if err := c.storage.SetFoodPrice(food.ID, price); err != nil {
+			return food, true, fmt.Errorf("%s: %w", op, mapStorageError(err))
+		}
+		food.Price = price
+		return food, true, nil
+	}
+	if !errors.Is(err, services.ErrFoodNotFound) {
+		return models.Food{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Блюда с таким названием нет - цена ингредиента
+	foods, err := c.storage.GetFoods()
+	if err != nil {
+		return models.Food{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+	name = strings.TrimSpace(name)
+	key := models.PriceKey(name)
+	known := slices.ContainsFunc(foods, func(food models.Food) bool {
+		return slices.ContainsFunc(food.Ingredients, func(ingredient string) bool { return models.PriceKey(ingredient) == key })
+	})
+	if !known {
+		return models.Food{}, false, fmt.Errorf("%s: %w: %q", op, services.ErrFoodNotFound, name)
+	}
+	if err := c.storage.SetIngredientPrice(key, price); err != nil {
+		return models.Food{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+	c.log.Info("ingredient price saved", slog.String("ingredient", key), slog.Int("price", price))
+	return models.Food{}, false, nil
+}
+
+// FoodByName отдает блюдо по названию без учета регистра
+func (c *Catalog) FoodByName(name string) (models.Food, error) {
+	const op = "Catalog.FoodByName"
+
+	foods, err := c.storage.GetFoods()
+	if err != nil {
+		return models.Food{}, fmt.Errorf("%s: %w", op, err)
+	}
+	name = strings.TrimSpace(name)
+	for _, food := range foods {
+		if strings.EqualFold(food.Name, name) {
+			return food, nil
+		}
+	}
+	return models.Food{}, fmt.Errorf("%s: %w: %q", op, services.ErrFoodNotFound, name)
+}
+
+// Pairings отдает сочетания мяса и гарниров с необычным весом
+func (c *Catalog) Pairings() ([]models.Pairing, error) {
+	const op = "Catalog.Pairings"
+
+	pairings, err := c.storage.GetPairings()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return pairings, nil
+}
+
+// SetPairing проверяет и сохраняет вес сочетания мяса и гарнира.
+// Блюда можно указать в любом порядке, в ответе сначала идет мясо.
+func (c *Catalog) SetPairing(pairing models.Pairing) (models.Pairing, error) {
+	const op = "Catalog.SetPairing"
+
+	if pairing.Weight < models.PairForbidden || pairing.Weight > models.MaxPairWeight {
+		return pairing, fmt.Errorf("%s: %w: weight %d", op, services.ErrInvalidPairing, pairing.Weight)
+	}
+	foods, err := c.storage.GetFoods()
+	if err != nil {
+		return pairing, fmt.Errorf("%s: %w", op, err)
+	}
+	categories := make(map[int64]models.FootCategory, len(foods))
+	for _, food := range foods {
+		categories[food.ID] = food.Category
+	}
+	meat, okMeat := categories[pairing.MeatID]
+	side, okSide := categories[pairing.SideID]
+	if !okMeat || !okSide {
+		return pairing, fmt.Errorf("%s: %w", op, services.ErrFoodNotFound)
+	}
+	if meat == models.SideDish && side == models.Meat {
+		pairing.MeatID, pairing.SideID = pairing.SideID, pairing.MeatID
+		meat, side = side, meat
+	}
+	if meat != models.Meat || side != models.SideDish {
+		return pairing, fmt.Errorf("%s: %w: need meat and side dish", op, services.ErrInvalidPairing)
+	}
+	if err := c.storage.SetPairing(pairing); err != nil {
+		return pairing, fmt.Errorf("%s: %w", op, err)
+	}
+	c.log.Info("pairing saved",
+		slog.Int64("meat", pairing.MeatID),
+		slog.Int64("side", pairing.SideID),
+		slog.Int("weight", pairing.Weight),
+	)
+	return pairing, nil
+}
+
+// checkFood проверяет блюдо и существование его категории
+func (c *Catalog) checkFood(food models.Food) (models.Food, error) {
+	categories, err := c.storage.GetCategories()
+	if err != nil {
+		return food, err
+	}
+	if !slices.ContainsFunc(categories, func(category models.Category) bool { return category.ID == food.Category }) {
+		return food, fmt.Errorf("%w: unknown category %d", services.ErrInvalidCatalog, food.Category)
+	}
+	food, err = normalizeFood(food)
+	if err != nil {
+		return food, fmt.Errorf("%w: %w", services.ErrInvalidCatalog, err)
+	}
+	return food, nil
+}
+
+// mapStorageError переводит ошибки хранилища в ошибки сервиса
+func mapStorageError(err error) error {
+	switch {
+	case errors.Is(err, storages.ErrFoodNotFound):
+		return services.ErrFoodNotFound
+	case errors.Is(err, storages.ErrFoodExists):
+		return services.ErrFoodExists
+	}
+	return err
+}
+
+// validate проверяет записи файла и переводит их в модели.
+// Теги и ингредиенты приводятся к нижнему регистру и сортируются,
+// чтобы повторный импорт выгруженного каталога не давал изменений.
+func validate(records []record, categories []models.Category) ([]models.Food, error) {
+	ids := make(map[string]models.FootCategory, len(categories))
+	for _, category := range categories {
+		ids[strings.ToLower(category.Name)] = category.ID
+	}
+
+	foods := make([]models.Food, 0, len(records))
+	seen := make(map[string]struct{}, len(records))
+	for i, r := range records {
+		category, ok := ids[strings.ToLower(strings.TrimSpace(r.Category))]
+		if !ok {
+			return nil, fmt.Errorf("%w: record %d: unknown category %q", services.ErrInvalidCatalog, i+1, r.Category)
+		}
+		seasons, err := models.ParseSeasons(r.Seasons)
+		if err != nil {
+			return nil, fmt.Errorf("%w: record %d: %w", services.ErrInvalidCatalog, i+1, err)
+		}
+		weekdays, err := models.ParseWeekdays(r.Weekdays)
+		if err != nil {
+			return nil, fmt.Errorf("%w: record %d: %w", services.ErrInvalidCatalog, i+1, err)
+		}
+		food, err := normalizeFood(models.Food{
+			Name:        r.Name,
+			Category:    category,
+			Tags:        r.Tags,
+			Ingredients: r.Ingredients,
+			Names:       r.Names,
+			Recipe:      r.Recipe,
+			Nutrition:   r.Nutrition.model(),
+			Seasons:     seasons,
+			Weekdays:    weekdays,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: record %d: %w", services.ErrInvalidCatalog, i+1, err)
+		}
+		if _, ok := seen[food.Name]; ok {
+			return nil, fmt.Errorf("%w: record %d: duplicate food %q", services.ErrInvalidCatalog, i+1, food.Name)
+		}
+		seen[food.Name] = struct{}{}
+		foods = append(foods, food)
+	}
+	return foods, nil
+}
+
+// normalizeFood проверяет блюдо и приводит его поля к единому виду
+func normalizeFood(food models.Food) (models.Food, error) {
+	var err error
+	food.Name = strings.TrimSpace(food.Name)
+	if food.Name == "" {
+		return food, errors.New("empty name")
+	}
+	if food.Tags, err = normalizeList(food.Tags); err != nil {
+		return food, fmt.Errorf("tags: %w", err)
+	}
+	if food.Ingredients, err = normalizeList(food.Ingredients); err != nil {
+		return food, fmt.Errorf("ingredients: %w", err)
+	}
+	if food.Names, err = normalizeNames(food.Names); err != nil {
+		return food, fmt.Errorf("names: %w", err)
+	}
+	if n := food.Nutrition; n != nil && (n.Kcal < 0 || n.Protein < 0 || n.Fat < 0 || n.Carbs < 0) {
+		return food, errors.New("nutrition values must not be negative")
+	}
+	food.Recipe = strings.TrimSpace(food.Recipe)
+	if food.Recipe != "" && !isURL(food.Recipe) {
+		return food, fmt.Errorf("recipe %q is not a http(s) link", food.Recipe)
+	}
+	for _, season := range food.Seasons {
+		if season < models.Winter || season > models.Autumn {
+			return food, fmt.Errorf("unknown season %d", season)
+		}
+	}
+	for _, day := range food.Weekdays {
+		if day < time.Sunday || day > time.Saturday {
+			return food, fmt.Errorf("unknown weekday %d", day)
+		}
+	}
+	food.Seasons = sortedSet(food.Seasons)
+	food.Weekdays = sortedSet(food.Weekdays)
+	return food, nil
+}
+
+// sortedSet сортирует значения и убирает повторы, пустой список становится nil
+func sortedSet[T cmp.Ordered](values []T) []T {
+	if len(values) == 0 {
+		return nil
+	}
+	res := slices.Clone(values)
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+// normalizeList приводит значения к нижнему регистру, убирает повторы и сортирует
+func normalizeList(values []string) ([]string, error) {
+	res := models.NormalizeList(values)
+	for _, value := range res {
+		if strings.Contains(value, listSeparator) {
+			return nil, fmt.Errorf("value %q contains %q", value, listSeparator)
+		}
+	}
+	return res, nil
+}
+
+// isURL проверяет, что value - абсолютная http(s) ссылка
+func isURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// normalizeNames проверяет переводы названия: код языка в нижнем регистре,
+// перевод не пустой и не содержит разделителей CSV
+func normalizeNames(names map[string]string) (map[string]string, error) {
+	res := make(map[string]string, len(names))
+	for lang, name := range names {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		name = strings.TrimSpace(name)
+		if lang == "" || name == "" {
+			continue
+		}
+		if strings.ContainsAny(lang+name, listSeparator+nameSeparator) {
+			return nil, fmt.Errorf("translation %s=%q contains %q or %q", lang, name, listSeparator, nameSeparator)
+		}
+		res[lang] = name
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return res, nil
+}
+
+// compare сравнивает блюда из файла с блюдами в БД по названию
+func compare(current []models.Food, foods []models.Food) models.CatalogDiff {
+	diff := models.CatalogDiff{}
+	byName := make(map[string]models.Food, len(current))
+	for _, food := range current {
+		byName[food.Name] = food
+	}
+	for _, food := range foods {
+		old, ok := byName[food.Name]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, food)
+		case old.Category != food.Category ||
+			!slices.Equal(old.Tags, food.Tags) ||
+			!slices.Equal(old.Ingredients, food.Ingredients) ||
+			!maps.Equal(old.Names, food.Names) ||
+			old.Recipe != food.Recipe ||
+			!sameNutrition(old.Nutrition, food.Nutrition) ||
+			!slices.Equal(old.Seasons, food.Seasons) ||
+			!slices.Equal(old.Weekdays, food.Weekdays):
+			diff.Updated = append(diff.Updated, models.FoodUpdate{Old: old, New: food})
+		default:
+			diff.Unchanged++
+		}
+	}
+	return diff
+}
+
+// DiffText формирует текстовое описание изменений каталога
+func DiffText(diff models.CatalogDiff) string {
+	var sb strings.Builder
+	for _, food := range diff.Added {
+		fmt.Fprintf(&sb, "+ %s\n", food.Name)
+	}
+	for _, update := range diff.Updated {
+		fmt.Fprintf(&sb, "~ %s", update.New.Name)
+		if update.Old.Category != update.New.Category {
+			fmt.Fprintf(&sb, " category: %d -> %d", update.Old.Category, update.New.Category)
+		}
+		if !slices.Equal(update.Old.Tags, update.New.Tags) {
+			fmt.Fprintf(&sb, " tags: [%s] -> [%s]", strings.Join(update.Old.Tags, ", "), strings.Join(update.New.Tags, ", "))
+		}
+		if !slices.Equal(update.Old.Ingredients, update.New.Ingredients) {
+			fmt.Fprintf(&sb, " ingredients: [%s] -> [%s]", strings.Join(update.Old.Ingredients, ", "), strings.Join(update.New.Ingredients, ", "))
+		}
+		if !maps.Equal(update.Old.Names, update.New.Names) {
+			fmt.Fprintf(&sb, " names: [%s] -> [%s]", joinNames(update.Old.Names), joinNames(update.New.Names))
+		}
+		if update.Old.Recipe != update.New.Recipe {
+			fmt.Fprintf(&sb, " recipe: %q -> %q", update.Old.Recipe, update.New.Recipe)
+		}
+		if !sameNutrition(update.Old.Nutrition, update.New.Nutrition) {
+			fmt.Fprintf(&sb, " nutrition: [%s] -> [%s]",
+				strings.Join(formatNutrition(toNutritionRecord(update.Old.Nutrition)), ", "),
+				strings.Join(formatNutrition(toNutritionRecord(update.New.Nutrition)), ", "))
+		}
+		if !slices.Equal(update.Old.Seasons, update.New.Seasons) {
+			fmt.Fprintf(&sb, " seasons: [%s] -> [%s]",
+				strings.Join(models.SeasonNames(update.Old.Seasons), ", "),
+				strings.Join(models.SeasonNames(update.New.Seasons), ", "))
+		}
+		if !slices.Equal(update.Old.Weekdays, update.New.Weekdays) {
+			fmt.Fprintf(&sb, " weekdays: [%s] -> [%s]",
+				strings.Join(models.WeekdayNames(update.Old.Weekdays), ", "),
+				strings.Join(models.WeekdayNames(update.New.Weekdays), ", "))
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "added %d, updated %d, unchanged %d", len(diff.Added), len(diff.Updated), diff.Unchanged)
+	return sb.String()
+}
+
+// sameNutrition сравнивает пищевую ценность, nil равен только nil
+func sameNutrition(a, b *models.Nutrition) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func toNutritionRecord(n *models.Nutrition) *nutritionRecord {
+	if n == nil {
+		return nil
+	}
+	return &nutritionRecord{Kcal: n.Kcal, Protein: n.Protein, Fat: n.Fat, Carbs: n.Carbs}
+}
+
+func (r *nutritionRecord) model() *models.Nutrition {
+	if r == nil {
+		return nil
+	}
+	return &models.Nutrition{Kcal: r.Kcal, Protein: r.Protein, Fat: r.Fat, Carbs: r.Carbs}
+}
@@ -0,0 +1,267 @@
+package catalogservice
+
+import (
+	"dinner/internal/services"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Формат файла каталога
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// Разделитель тегов и ингредиентов в колонках CSV
+const listSeparator = ";"
+
+// Разделитель языка и перевода названия в колонке names CSV: "en=Borscht"
+const nameSeparator = "="
+
+// Заголовок CSV файла.
+// Обязательны только колонки name и category, порядок колонок может быть любым.
+var csvHeader = []string{"name", "category", "tags", "ingredients", "names", "recipe", "kcal", "protein", "fat", "carbs", "seasons", "weekdays"}
+
+// Одно блюдо в файле каталога
+type record struct {
+	Name        string   `yaml:"name" json:"name"`
+	Category    string   `yaml:"category" json:"category"`
+	Tags        []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Ingredients []string `yaml:"ingredients,omitempty" json:"ingredients,omitempty"`
+	// Переводы названия по коду языка
+	Names map[string]string `yaml:"names,omitempty" json:"names,omitempty"`
+	// Ссылка на рецепт
+	Recipe string `yaml:"recipe,omitempty" json:"recipe,omitempty"`
+	// Пищевая ценность порции
+	Nutrition *nutritionRecord `yaml:"nutrition,omitempty" json:"nutrition,omitempty"`
+	// Времена года ("winter", "summer"), пустой - круглый год
+	Seasons []string `yaml:"seasons,omitempty" json:"seasons,omitempty"`
+	// Предпочтительные дни недели ("thu")
+	Weekdays []string `yaml:"weekdays,omitempty" json:"weekdays,omitempty"`
+}
+
+// Пищевая ценность порции в файле каталога
+type nutritionRecord struct {
+	Kcal    float64 `yaml:"kcal" json:"kcal"`
+	Protein float64 `yaml:"protein" json:"protein"`
+	Fat     float64 `yaml:"fat" json:"fat"`
+	Carbs   float64 `yaml:"carbs" json:"carbs"`
+}
+
+// Структура JSON и YAML файлов каталога
+type document struct {
+	Foods []record `yaml:"foods" json:"foods"`
+}
+
+// ParseFormat определяет формат по названию ("csv", "json", "yaml")
+// или по расширению файла
+func ParseFormat(value string) (Format, error) {
+	const op = "catalogservice.ParseFormat"
+
+	name := strings.ToLower(strings.TrimPrefix(filepath.Ext(value), "."))
+	if name == "" {
+		name = strings.ToLower(value)
+	}
+	switch name {
+	case "csv":
+		return FormatCSV, nil
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	}
+	return "", fmt.Errorf("%s: %w: %s", op, services.ErrUnknownFormat, value)
+}
+
+// encode записывает блюда в w в формате format
+func encode(format Format, w io.Writer, records []record) error {
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvHeader); err != nil {
+			return err
+		}
+		for _, r := range records {
+			row := []string{
+				r.Name,
+				r.Category,
+				strings.Join(r.Tags, listSeparator),
+				strings.Join(r.Ingredients, listSeparator),
+				joinNames(r.Names),
+				r.Recipe,
+			}
+			row = append(row, formatNutrition(r.Nutrition)...)
+			row = append(row, strings.Join(r.Seasons, listSeparator), strings.Join(r.Weekdays, listSeparator))
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(document{Foods: records})
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(document{Foods: records}); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	return services.ErrUnknownFormat
+}
+
+// decode читает блюда из r в формате format
+func decode(format Format, r io.Reader) ([]record, error) {
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return nil, nil
+		}
+		columns := map[string]int{}
+		for i, column := range rows[0] {
+			column = strings.ToLower(strings.TrimSpace(column))
+			if !slices.Contains(csvHeader, column) {
+				return nil, fmt.Errorf("%w: unknown csv column %q", services.ErrInvalidCatalog, column)
+			}
+			columns[column] = i
+		}
+		if _, ok := columns["name"]; !ok {
+			return nil, fmt.Errorf("%w: csv column \"name\" is required", services.ErrInvalidCatalog)
+		}
+		if _, ok := columns["category"]; !ok {
+			return nil, fmt.Errorf("%w: csv column \"category\" is required", services.ErrInvalidCatalog)
+		}
+		value := func(row []string, column string) string {
+			if i, ok := columns[column]; ok {
+				return row[i]
+			}
+			return ""
+		}
+
+		records := make([]record, 0, len(rows)-1)
+		for i, row := range rows[1:] {
+			names, err := splitNames(value(row, "names"))
+			if err != nil {
+				return nil, fmt.Errorf("%w: record %d: %w", services.ErrInvalidCatalog, i+1, err)
+			}
+			nutrition, err := parseNutrition(value(row, "kcal"), value(row, "protein"), value(row, "fat"), value(row, "carbs"))
+			if err != nil {
+				return nil, fmt.Errorf("%w: record %d: %w", services.ErrInvalidCatalog, i+1, err)
+			}
+			records = append(records, record{
+				Name:        value(row, "name"),
+				Category:    value(row, "category"),
+				Tags:        splitList(value(row, "tags")),
+				Ingredients: splitList(value(row, "ingredients")),
+				Names:       names,
+				Recipe:      value(row, "recipe"),
+				Nutrition:   nutrition,
+				Seasons:     splitList(value(row, "seasons")),
+				Weekdays:    splitList(value(row, "weekdays")),
+			})
+		}
+		return records, nil
+	case FormatJSON:
+		var doc document
+		if err := json.NewDecoder(r).Decode(&doc); err != nil {
+			return nil, err
+		}
+		return doc.Foods, nil
+	case FormatYAML:
+		var doc document
+		if err := yaml.NewDecoder(r).Decode(&doc); err != nil && err != io.EOF {
+			return nil, err
+		}
+		return doc.Foods, nil
+	}
+	return nil, services.ErrUnknownFormat
+}
+
+// splitList разбивает колонку CSV на список значений
+func splitList(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return strings.Split(value, listSeparator)
+}
+
+// joinNames записывает переводы названия в колонку CSV: "en=Borscht;uk=Борщ"
+func joinNames(names map[string]string) string {
+	langs := slices.Sorted(maps.Keys(names))
+	parts := make([]string, 0, len(langs))
+	for _, lang := range langs {
+		parts = append(parts, lang+nameSeparator+names[lang])
+	}
+	return strings.Join(parts, listSeparator)
+}
+
+// splitNames разбирает колонку CSV с переводами названия
+func splitNames(value string) (map[string]string, error) {
+	items := splitList(value)
+	if len(items) == 0 {
+		return nil, nil
+	}
+	names := make(map[string]string, len(items))
+	for _, item := range items {
+		lang, name, ok := strings.Cut(item, nameSeparator)
+		if !ok {
+			return nil, fmt.Errorf("name translation %q must be in form lang%sname", item, nameSeparator)
+		}
+		names[lang] = name
+	}
+	return names, nil
+}
+
+// formatNutrition записывает пищевую ценность в колонки CSV kcal, protein, fat, carbs
+func formatNutrition(n *nutritionRecord) []string {
+	if n == nil {
+		return []string{"", "", "", ""}
+	}
+	values := []float64{n.Kcal, n.Protein, n.Fat, n.Carbs}
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		res = append(res, strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return res
+}
+
+// parseNutrition разбирает колонки CSV с пищевой ценностью.
+// Все пустые колонки означают, что пищевая ценность не указана.
+func parseNutrition(kcal, protein, fat, carbs string) (*nutritionRecord, error) {
+	columns := []string{kcal, protein, fat, carbs}
+	if !slices.ContainsFunc(columns, func(v string) bool { return strings.TrimSpace(v) != "" }) {
+		return nil, nil
+	}
+	values := make([]float64, len(columns))
+	for i, column := range columns {
+		if strings.TrimSpace(column) == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(column), 64)
+		if err != nil {
+			return nil, fmt.Errorf("nutrition value %q is not a number", column)
+		}
+		values[i] = v
+	}
+	return &nutritionRecord{Kcal: values[0], Protein: values[1], Fat: values[2], Carbs: values[3]}, nil
+}
@@ -0,0 +1,118 @@
+package catalogservice
+
+import (
+	"cmp"
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/stem"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Насколько блюдо подходит под запрос поиска, от лучшего совпадения к худшему
+const (
+	scoreExact      = 100
+	scorePrefix     = 90
+	scoreSubstring  = 80
+	scoreWords      = 70
+	scoreDetails    = 50
+	scoreFuzzy      = 30
+	scoreFuzzyExtra = 20
+)
+
+// Search ищет блюда по названию на любом языке, а также по тегам и ингредиентам.
+// Регистр и "ё" не учитываются, слова сравниваются с точностью до окончания
+// и с опечатками (см. stem.Similar). Блюда сортируются от лучшего совпадения,
+// limit ограничивает количество (0 - без ограничения).
+func (c *Catalog) Search(query string, limit int) ([]models.Food, error) {
+	const op = "Catalog.Search"
+
+	foods, err := c.storage.GetFoods()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	query = normalize(query)
+	words := stem.Phrase(query)
+	if len(words) == 0 {
+		return []models.Food{}, nil
+	}
+
+	type found struct {
+		food  models.Food
+		score int
+	}
+	res := make([]found, 0)
+	for _, food := range foods {
+		if score := searchScore(food, query, words); score > 0 {
+			res = append(res, found{food: food, score: score})
+		}
+	}
+	slices.SortStableFunc(res, func(a, b found) int {
+		return cmp.Or(b.score-a.score, strings.Compare(a.food.Name, b.food.Name))
+	})
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	list := make([]models.Food, 0, len(res))
+	for _, f := range res {
+		list = append(list, f.food)
+	}
+	return list, nil
+}
+
+// searchScore оценивает, насколько блюдо food подходит под запрос query с основами words.
+// 0 - не подходит.
+func searchScore(food models.Food, query string, words []string) int {
+	names := []string{food.Name}
+	for _, name := range food.Names {
+		names = append(names, name)
+	}
+	score := 0
+	for _, name := range names {
+		name = normalize(name)
+		switch {
+		case name == query:
+			score = max(score, scoreExact)
+		case strings.HasPrefix(name, query):
+			score = max(score, scorePrefix)
+		case strings.Contains(name, query):
+			score = max(score, scoreSubstring)
+		}
+	}
+	if score > 0 {
+		return score
+	}
+
+	nameWords := stem.Phrase(strings.Join(names, " "))
+	detailWords := stem.Phrase(strings.Join(slices.Concat(food.Tags, food.Ingredients), " "))
+	prefix := func(a, b string) bool { return strings.HasPrefix(b, a) }
+	switch {
+	case allWords(words, nameWords, prefix):
+		return scoreWords
+	case allWords(words, detailWords, prefix):
+		return scoreDetails
+	case allWords(words, nameWords, stem.Similar):
+		return scoreFuzzy
+	case allWords(words, detailWords, stem.Similar):
+		return scoreFuzzyExtra
+	}
+	return 0
+}
+
+// allWords проверяет, что для каждой основы запроса в words есть подходящая основа в text
+func allWords(words []string, text []string, match func(word, textWord string) bool) bool {
+	if len(text) == 0 {
+		return false
+	}
+	for _, word := range words {
+		if !slices.ContainsFunc(text, func(t string) bool { return match(word, t) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize приводит текст к виду для поиска: нижний регистр, "ё" заменена на "е"
+func normalize(text string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(text)), "ё", "е")
+}
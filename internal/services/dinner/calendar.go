@@ -0,0 +1,43 @@
+package dinnerservice
+
+import (
+	"dinner/internal/domain/models"
+	"slices"
+	"time"
+)
+
+// Во сколько раз чаще предлагаются блюда, предпочтительные в текущий день недели
+const WeekdayBoost = 3
+
+// calendarFoods отбирает блюда для даты date.
+// Блюда не по сезону исключаются, если по сезону есть хоть одно блюдо.
+// Блюда, предпочтительные в этот день недели, повторяются WeekdayBoost раз,
+// чтобы при случайном выборе попадаться чаще.
+// Нулевая дата оставляет список без изменений.
+// Размеры выборки после отбора по сезону и дню недели записываются в trace (nil - без записи).
+func calendarFoods(foods []models.Food, date time.Time, trace *models.Trace) []models.Food {
+	if date.IsZero() {
+		return foods
+	}
+	season := models.SeasonOf(date)
+	seasonal := slices.DeleteFunc(slices.Clone(foods), func(food models.Food) bool {
+		return !food.InSeason(season)
+	})
+	if len(seasonal) == 0 {
+		seasonal = slices.Clone(foods)
+	}
+	trace.AddStep(models.TraceSeason, len(seasonal))
+	res := make([]models.Food, 0, len(seasonal))
+	for _, food := range seasonal {
+		res = append(res, food)
+		if food.PreferredOn(date.Weekday()) {
+			for range WeekdayBoost - 1 {
+				res = append(res, food)
+			}
+		}
+	}
+	if len(res) > len(seasonal) {
+		trace.AddStep(models.TraceWeekday, len(res))
+	}
+	return res
+}
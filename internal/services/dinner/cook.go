@@ -0,0 +1,84 @@
+package dinnerservice
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/stem"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Минимальная доля имеющихся ингредиентов, с которой ужин предлагается
+const MinCookCoverage = 0.5
+
+// Cook подбирает ужины из продуктов have и сортирует их по доле имеющихся ингредиентов.
+// Ужины составляются так же, как в GetRandomDinner: суп, салат или мясо с гарниром.
+// Запрещенные сочетания мяса и гарнира не предлагаются.
+// Блюда без ингредиентов не учитываются. Запрос не входит в лимит и не сохраняется в истории.
+func (d *Dinner) Cook(have []string, limit int) ([]models.CookOption, error) {
+	const op = "Dinner.Cook"
+
+	foods, err := d.foodProvider.GetFoods()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	pairings, err := d.pairings()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	options := make([]models.CookOption, 0)
+	for _, dinner := range cookDinners(foods, pairings) {
+		option := cookOption(dinner, have)
+		if option.Coverage >= MinCookCoverage {
+			options = append(options, option)
+		}
+	}
+	slices.SortStableFunc(options, func(a, b models.CookOption) int {
+		switch {
+		case a.Coverage != b.Coverage:
+			if a.Coverage > b.Coverage {
+				return -1
+			}
+			return 1
+		case len(a.Missing) != len(b.Missing):
+			return len(a.Missing) - len(b.Missing)
+		}
+		return strings.Compare(a.Foods[0].Name, b.Foods[0].Name)
+	})
+	if limit > 0 && len(options) > limit {
+		options = options[:limit]
+	}
+	return options, nil
+}
+
+// cookDinners перечисляет все возможные ужины из блюд с ингредиентами
+// без запрещенных сочетаний мяса и гарнира
+func cookDinners(foods []models.Food, pairings models.Pairings) [][]models.Food {
+	return allDinners(slices.DeleteFunc(slices.Clone(foods), func(food models.Food) bool {
+		return len(food.Ingredients) == 0
+	}), pairings)
+}
+
+// cookOption считает, каких ингредиентов ужина хватает
+func cookOption(dinner []models.Food, have []string) models.CookOption {
+	option := models.CookOption{Foods: dinner}
+	seen := make(map[string]struct{})
+	for _, food := range dinner {
+		for _, ingredient := range food.Ingredients {
+			if _, ok := seen[ingredient]; ok {
+				continue
+			}
+			seen[ingredient] = struct{}{}
+			if slices.ContainsFunc(have, func(h string) bool { return stem.Match(h, ingredient) }) {
+				option.Have = append(option.Have, ingredient)
+			} else {
+				option.Missing = append(option.Missing, ingredient)
+			}
+		}
+	}
+	if total := len(option.Have) + len(option.Missing); total > 0 {
+		option.Coverage = float64(len(option.Have)) / float64(total)
+	}
+	return option
+}
@@ -5,15 +5,39 @@ package dinnerservice
 import (
 	"dinner/internal/domain/models"
 	"dinner/internal/services"
+	"dinner/internal/storages"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/rand/v2"
+	"slices"
+	"sync"
 )
 
+// Максимальное количество дней в плане ужинов
+const MaxPlanDays = 14
+
+// Максимальная оценка блюда
+const MaxRating = 5
+
+// Максимальное количество вариантов ужина в GetSuggestions
+const MaxSuggestions = 10
+
+// Во сколько раз попыток подбора больше, чем нужно вариантов:
+// случайный выбор может повторяться, а в маленьком каталоге разных ужинов мало
+const suggestionAttempts = 5
+
 type Dinner struct {
 	log             *slog.Logger
 	foodProvider    FoodProvider
 	historyProvider HistoryProvider
+	pairingProvider PairingProvider
+	profileProvider ProfileProvider
+	// Источник случайных чисел, общий для всех запросов
+	mu  sync.Mutex
+	rnd *rand.Rand
+	// Зерно источника, если он создан через WithSeed
+	seed uint64
 }
 
 // Доступ к списку доступных блюд
@@ -23,32 +47,316 @@ type FoodProvider interface {
 
 // Доступ к истории запросов пользователей
 type HistoryProvider interface {
-	SaveRequest(userId int64) error
+	SaveRequest(userId int64, foods []models.Food) error
+	SavePlanRequest(userId int64, foods []models.Food) error
 	IsLimit(userId int64) (bool, error)
 }
 
-// New - конструктор сервиса
+// Доступ к таблице сочетаний мяса и гарниров
+type PairingProvider interface {
+	GetPairings() ([]models.Pairing, error)
+}
+
+// Доступ к оценкам блюд и истории пользователя для стратегий выбора
+type ProfileProvider interface {
+	GetHistory(userId int64) ([]models.HistoryEntry, error)
+	GetRatings(userId int64) (map[int64]int, error)
+	SetRating(userId int64, foodId int64, rating int) error
+	GetQueue(userId int64) (models.FoodQueue, error)
+	SaveQueue(userId int64, queue models.FoodQueue) error
+}
+
+// New - конструктор сервиса.
+// pairingProvider - таблица сочетаний мяса и гарниров, nil - все сочетания обычные.
+// profileProvider - оценки и история пользователя, nil - стратегии выбора
+// работают без оценок и истории.
+// source - источник случайных чисел, nil - случайный источник (см. NewSource).
 func New(
 	log *slog.Logger,
 	foodProvider FoodProvider,
 	historyProvider HistoryProvider,
+	pairingProvider PairingProvider,
+	profileProvider ProfileProvider,
+	source rand.Source,
 ) *Dinner {
+	if source == nil {
+		source = NewSource(0)
+	}
 	return &Dinner{
 		log:             log,
 		foodProvider:    foodProvider,
 		historyProvider: historyProvider,
+		pairingProvider: pairingProvider,
+		profileProvider: profileProvider,
+		rnd:             rand.New(source),
+	}
+}
+
+// NewSource создает источник случайных чисел с зерном seed.
+// При seed == 0 зерно выбирается случайно.
+func NewSource(seed uint64) rand.Source {
+	if seed == 0 {
+		return rand.NewPCG(rand.Uint64(), rand.Uint64())
+	}
+	return rand.NewPCG(seed, seed)
+}
+
+// WithSeed отдает копию сервиса с собственным источником случайных чисел с зерном seed.
+// Одинаковые seed и каталог дают одинаковый результат, что позволяет воспроизвести подбор.
+func (d *Dinner) WithSeed(seed uint64) *Dinner {
+	res := New(d.log, d.foodProvider, d.historyProvider, d.pairingProvider, d.profileProvider, NewSource(seed))
+	res.seed = seed
+	return res
+}
+
+// NextSeed отдает зерно для следующего запроса из общего источника.
+// Его можно сохранить в логе или ответе и повторить подбор через WithSeed.
+func (d *Dinner) NextSeed() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for {
+		if seed := d.rnd.Uint64(); seed != 0 {
+			return seed
+		}
+	}
+}
+
+// intN отдает случайное число из [0, n)
+func (d *Dinner) intN(n int) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.rnd.IntN(n)
+}
+
+// pairings отдает таблицу сочетаний мяса и гарниров
+func (d *Dinner) pairings() (models.Pairings, error) {
+	if d.pairingProvider == nil {
+		return models.Pairings{}, nil
+	}
+	list, err := d.pairingProvider.GetPairings()
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPairings(list), nil
+}
+
+// strategy отдает стратегию выбора opts.Mode и профиль пользователя userId для нее.
+// Очередь блюд для Shuffle сверяется с каталогом foods.
+func (d *Dinner) strategy(userId int64, foods []models.Food, opts Options) (Strategy, Profile, error) {
+	strategy, ok := StrategyByName(opts.Mode)
+	if !ok {
+		return nil, Profile{}, fmt.Errorf("%w: %s", services.ErrUnknownMode, opts.Mode)
+	}
+	if d.profileProvider == nil {
+		return strategy, Profile{}, nil
+	}
+	var profile Profile
+	if strategy.NeedsProfile() {
+		ratings, err := d.profileProvider.GetRatings(userId)
+		if err != nil {
+			return nil, Profile{}, err
+		}
+		history, err := d.profileProvider.GetHistory(userId)
+		if err != nil {
+			return nil, Profile{}, err
+		}
+		profile = NewProfile(ratings, history)
+	}
+	if _, ok := strategy.(Shuffle); ok {
+		queue, err := d.profileProvider.GetQueue(userId)
+		if err != nil {
+			return nil, Profile{}, err
+		}
+		profile.Queue = syncQueue(queue, foods, d.intN)
+	}
+	return strategy, profile, nil
+}
+
+// saveQueue отмечает блюда served в очереди профиля и сохраняет ее.
+// Если у стратегии нет очереди, ничего не делает.
+func (d *Dinner) saveQueue(userId int64, profile Profile, served []models.Food) error {
+	if profile.Queue == nil {
+		return nil
+	}
+	profile.Queue.MarkServed(served)
+	return d.profileProvider.SaveQueue(userId, profile.Queue)
+}
+
+// Rate сохраняет оценку rating блюда foodId от пользователя userId.
+// Оценка от 1 до 5, 0 - убрать оценку.
+func (d *Dinner) Rate(userId int64, foodId int64, rating int) error {
+	const op = "Dinner.Rate"
+
+	if rating < 0 || rating > MaxRating {
+		return fmt.Errorf("%s: %w: %d", op, services.ErrInvalidRating, rating)
 	}
+	if d.profileProvider == nil {
+		return fmt.Errorf("%s: ratings are not supported", op)
+	}
+	if err := d.profileProvider.SetRating(userId, foodId, rating); err != nil {
+		if errors.Is(err, storages.ErrFoodNotFound) {
+			return fmt.Errorf("%s: %w", op, services.ErrFoodNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Ratings отдает оценки блюд пользователя userId по id блюда
+func (d *Dinner) Ratings(userId int64) (map[int64]int, error) {
+	const op = "Dinner.Ratings"
+
+	if d.profileProvider == nil {
+		return map[int64]int{}, nil
+	}
+	ratings, err := d.profileProvider.GetRatings(userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return ratings, nil
 }
 
 // GetRandomDinner отдает массив блюд на ужин для юзера userId.
 func (d *Dinner) GetRandomDinner(userId int64) ([]models.Food, error) {
-	const op = "Dinner.GetRandomDinner"
+	return d.GetDinner(userId, Options{})
+}
+
+// GetDinner отдает массив блюд на ужин для юзера userId с учетом условий opts.
+func (d *Dinner) GetDinner(userId int64, opts Options) ([]models.Food, error) {
+	food, _, err := d.GetDinnerTrace(userId, opts)
+	return food, err
+}
+
+// GetDinnerTrace подбирает ужин так же, как GetDinner, и объясняет подбор:
+// сколько вариантов осталось после каждого шага отбора и как выпал случайный выбор.
+// Объяснение пишется в лог на уровне Debug.
+func (d *Dinner) GetDinnerTrace(userId int64, opts Options) ([]models.Food, models.Trace, error) {
+	const op = "Dinner.GetDinnerTrace"
 
 	log := d.log.With(
 		slog.String("op", op),
 	)
 	// проверка на лимит запросов
 	limit, err := d.historyProvider.IsLimit(userId)
+	if err != nil {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if !limit {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, services.ErrAttemptLimitExceeded)
+	}
+
+	// Запрос списка доступных блюд
+	foods, err := d.foodProvider.GetFoods()
+	if err != nil {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	trace := models.Trace{Seed: d.seed}
+	trace.AddStep(models.TraceCatalog, len(foods))
+	// Проверка, что список блюд не пустой
+	if len(foods) == 0 {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, services.ErrEmptyFood)
+	}
+
+	pairings, err := d.pairings()
+	if err != nil {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+	strategy, profile, err := d.strategy(userId, foods, opts)
+	if err != nil {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+	trace.Strategy = strategy.Name()
+
+	food, err := d.pick(foods, pairings, strategy, profile, opts, &trace)
+	if err != nil {
+		log.Debug("dinner trace", slog.Any("trace", trace))
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	//Сохранение запроса пользователя и предложенных блюд в истории
+	err = d.historyProvider.SaveRequest(userId, food)
+	if err != nil {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := d.saveQueue(userId, profile, food); err != nil {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("select dinner save reques")
+	log.Debug("dinner trace", slog.Any("trace", trace))
+
+	return food, trace, nil
+}
+
+// GetDinnerWith составляет ужин вокруг выбранного пользователем блюда foodId:
+// к мясу подбирается гарнир, к гарниру - мясо так же, как в GetDinner,
+// суп и салат остаются одни. Пара подбирается с учетом ограничений opts.Exclude
+// и календаря, калории не учитываются. Запрос учитывается в лимите и сохраняется в истории,
+// в режиме opts.Mode с очередью блюда отмечаются в ней предложенными.
+func (d *Dinner) GetDinnerWith(userId int64, foodId int64, opts Options) ([]models.Food, models.Trace, error) {
+	const op = "Dinner.GetDinnerWith"
+
+	log := d.log.With(
+		slog.String("op", op),
+	)
+	limit, err := d.historyProvider.IsLimit(userId)
+	if err != nil {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if !limit {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, services.ErrAttemptLimitExceeded)
+	}
+	foods, err := d.foodProvider.GetFoods()
+	if err != nil {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+	i := slices.IndexFunc(foods, func(food models.Food) bool { return food.ID == foodId })
+	if i < 0 {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w: %d", op, services.ErrFoodNotFound, foodId)
+	}
+	pairings, err := d.pairings()
+	if err != nil {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+	_, profile, err := d.strategy(userId, foods, opts)
+	if err != nil {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	trace := models.Trace{Seed: d.seed}
+	trace.AddStep(models.TraceCatalog, len(foods))
+	food := []models.Food{foods[i]}
+	pool := calendarFoods(opts.allowed(foods), opts.Date, &trace)
+	switch food[0].Category {
+	case models.Meat:
+		food = d.completeDinner(food, GetSideDishes(&pool), pairings, &trace)
+	case models.SideDish:
+		food = d.completeDinner(food, GetMeats(&pool), pairings, &trace)
+	}
+
+	if err := d.historyProvider.SaveRequest(userId, food); err != nil {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := d.saveQueue(userId, profile, food); err != nil {
+		return nil, models.Trace{}, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("dinner with food save request", slog.Int64("food", foodId))
+	log.Debug("dinner trace", slog.Any("trace", trace))
+
+	return food, trace, nil
+}
+
+// GetSuggestions подбирает до n разных вариантов ужина для юзера userId с учетом условий opts,
+// например, чтобы предложить их на выбор в другом чате.
+// Варианты подбираются стратегией пользователя, если она повторяет один и тот же ужин
+// (например, RoundRobin), остальные варианты выбираются случайно.
+// Варианты расходуют лимит как один запрос на несколько ужинов, но их блюда
+// в истории не сохраняются: ни один из них еще не выбран на ужин.
+func (d *Dinner) GetSuggestions(userId int64, n int, opts Options) ([][]models.Food, error) {
+	const op = "Dinner.GetSuggestions"
+
+	n = min(max(n, 1), MaxSuggestions)
+	limit, err := d.historyProvider.IsLimit(userId)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -56,51 +364,382 @@ func (d *Dinner) GetRandomDinner(userId int64) ([]models.Food, error) {
 		return nil, fmt.Errorf("%s: %w", op, services.ErrAttemptLimitExceeded)
 	}
 
-	// Запрос списка доступных блюд
 	foods, err := d.foodProvider.GetFoods()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	if len(foods) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, services.ErrEmptyFood)
+	}
+	pairings, err := d.pairings()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	strategy, profile, err := d.strategy(userId, foods, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	suggestions := make([][]models.Food, 0, n)
+	for range n * suggestionAttempts {
+		if len(suggestions) == n {
+			break
+		}
+		dinner, err := d.pick(foods, pairings, strategy, profile, opts, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if slices.ContainsFunc(suggestions, func(s []models.Food) bool { return sameFoods(s, dinner) }) {
+			strategy = Random{}
+			continue
+		}
+		suggestions = append(suggestions, dinner)
+	}
+
+	if err := d.historyProvider.SavePlanRequest(userId, nil); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	d.log.Debug("dinner suggestions", slog.String("op", op), slog.Int("count", len(suggestions)))
 
-	//Сохранение запроса пользователя в истории
-	err = d.historyProvider.SaveRequest(userId)
+	return suggestions, nil
+}
+
+// GetWeeklyPlan отдает план ужинов на days дней для юзера userId без учета календаря.
+func (d *Dinner) GetWeeklyPlan(userId int64, days int) ([][]models.Food, error) {
+	return d.GetPlan(userId, days, Options{})
+}
+
+// GetPlan отдает план ужинов на days дней начиная с даты opts.Date для юзера userId.
+// Блюда в плане не повторяются, пока в каталоге есть неиспользованные.
+// Для каждого дня учитываются сезон и день недели (см. calendarFoods),
+// нулевая дата - без учета календаря. Блюда из opts.Exclude не предлагаются,
+// калории в плане не учитываются.
+// С бюджетом opts.Budget план старается уложиться в бюджет на days дней: каждый день
+// выбирается ужин не дороже оставшегося бюджета, деленного на оставшиеся дни,
+// а если такого нет - так же, как без бюджета.
+// План учитывается в лимите запросов как один запрос.
+func (d *Dinner) GetPlan(userId int64, days int, opts Options) ([][]models.Food, error) {
+	const op = "Dinner.GetPlan"
+
+	log := d.log.With(
+		slog.String("op", op),
+	)
+	if days < 1 || days > MaxPlanDays {
+		return nil, fmt.Errorf("%s: %w: %d", op, services.ErrInvalidPlanDays, days)
+	}
+	// проверка на лимит запросов
+	limit, err := d.historyProvider.IsLimit(userId)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	log.Info("select dinner save reques")
+	if !limit {
+		return nil, fmt.Errorf("%s: %w", op, services.ErrAttemptLimitExceeded)
+	}
 
-	// Проверка, что список блюд не пустой
+	// Запрос списка доступных блюд
+	foods, err := d.foodProvider.GetFoods()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 	if len(foods) == 0 {
 		return nil, fmt.Errorf("%s: %w", op, services.ErrEmptyFood)
 	}
+	strategy, profile, err := d.strategy(userId, foods, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if foods = opts.allowed(foods); len(foods) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, services.ErrNoMatchingDinner)
+	}
+	pairings, err := d.pairings()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	// Подучение случайного блюда
-	rndPos := rand.IntN(len(foods))
+	plan := make([][]models.Food, 0, days)
+	planned := make([]models.Food, 0, days*2)
+	pool := slices.Clone(foods)
+	budget := opts.Budget * days / DaysPerWeek
+	for day := range days {
+		// Все блюда использованы - начинаем сначала
+		if len(pool) == 0 {
+			pool = slices.Clone(foods)
+		}
+		date := opts.Date
+		if !date.IsZero() {
+			date = date.AddDate(0, 0, day)
+		}
+		dayFoods := calendarFoods(pool, date, nil)
+		var dinner []models.Food
+		if opts.Budget > 0 {
+			dinner = d.affordableDinner(dayFoods, pairings, strategy, profile, opts.Prices, budget/(days-day))
+		}
+		if len(dinner) == 0 {
+			dinner = d.selectDinner(dayFoods, pairings, strategy, profile, nil)
+		}
+		if len(dinner) == 0 {
+			return nil, fmt.Errorf("%s: %w", op, services.ErrEmptyFood)
+		}
+		if cost, ok := opts.Prices.DinnerCost(dinner); ok {
+			budget -= cost
+		}
+		plan = append(plan, dinner)
+		planned = append(planned, dinner...)
+		pool = slices.DeleteFunc(pool, func(food models.Food) bool {
+			return slices.ContainsFunc(dinner, func(f models.Food) bool { return f.Name == food.Name })
+		})
+	}
+
+	//Сохранение запроса пользователя и блюд плана в истории
+	err = d.historyProvider.SavePlanRequest(userId, planned)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := d.saveQueue(userId, profile, planned); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("weekly plan save request", slog.Int("days", days))
+
+	return plan, nil
+}
+
+// pick выбирает ужин стратегией strategy с учетом условий opts.
+// Блюда сначала отбираются по ограничениям opts.Exclude и календарю (см. calendarFoods).
+// Без ограничения калорий и стоимости используется selectDinner (selectFullDinner для ужина
+// из нескольких подач), иначе перечисляются все возможные сочетания блюд, и среди
+// подходящих под цель по калориям и бюджет (см. Options.affordable) ужин выбирается
+// так же, как без ограничений (см. chooseDinner).
+// Шаги отбора и случайные выборы записываются в trace (nil - без записи).
+func (d *Dinner) pick(foods []models.Food, pairings models.Pairings, strategy Strategy, profile Profile, opts Options, trace *models.Trace) ([]models.Food, error) {
+	if len(opts.Exclude) > 0 {
+		foods = opts.allowed(foods)
+		trace.AddStep(models.TraceRestrictions, len(foods))
+		if len(foods) == 0 {
+			return nil, services.ErrNoMatchingDinner
+		}
+	}
+	foods = calendarFoods(foods, opts.Date, trace)
+	kcal := opts.kcal()
+	budgeted := opts.budgeted(foods)
+	if kcal.IsZero() && !budgeted {
+		var dinner []models.Food
+		if opts.Full {
+			dinner = d.selectFullDinner(foods, pairings, strategy, profile, trace)
+		} else {
+			dinner = d.selectDinner(foods, pairings, strategy, profile, trace)
+		}
+		if len(dinner) == 0 {
+			return nil, services.ErrEmptyFood
+		}
+		return dinner, nil
+	}
+	var candidates [][]models.Food
+	if opts.Full {
+		candidates = d.fullDinners(foods, pairings, kcal)
+	} else {
+		candidates = allDinners(foods, pairings)
+	}
+	trace.AddStep(models.TraceDinners, len(candidates))
+	if !kcal.IsZero() {
+		candidates = slices.DeleteFunc(candidates, func(dinner []models.Food) bool {
+			total, ok := models.TotalNutrition(dinner)
+			return !ok || !kcal.Contains(total.Kcal)
+		})
+		trace.AddStep(models.TraceKcal, len(candidates))
+	}
+	if budgeted {
+		candidates = opts.affordable(candidates)
+		trace.AddStep(models.TraceBudget, len(candidates))
+	}
+	dinner, ok := d.chooseDinner(candidates, foods, pairings, strategy, profile, opts.Full, trace)
+	if !ok {
+		return nil, services.ErrNoMatchingDinner
+	}
+	return dinner, nil
+}
+
+// affordableDinner выбирает стратегией ужин из foods не дороже limit рублей
+// так же, как без ограничения стоимости (см. chooseDinner). nil - таких ужинов нет.
+func (d *Dinner) affordableDinner(foods []models.Food, pairings models.Pairings, strategy Strategy, profile Profile, prices models.Prices, limit int) []models.Food {
+	candidates := slices.DeleteFunc(allDinners(foods, pairings), func(dinner []models.Food) bool {
+		cost, ok := prices.DinnerCost(dinner)
+		return !ok || cost > limit
+	})
+	dinner, _ := d.chooseDinner(candidates, foods, pairings, strategy, profile, false, nil)
+	return dinner
+}
+
+// chooseDinner выбирает один из ужинов candidates, составленных из блюд foods, так же,
+// как selectDinner и selectFullDinner выбирают из всего каталога: сначала стратегией первое
+// блюдо - каждое блюдо foods, которое есть хотя бы в одном ужине, с равным шансом (для ужина
+// из нескольких подач - мясо или гарнир), затем пара к мясу или гарниру с учетом веса сочетания,
+// затем стратегией закуска и суп. После каждого выбора остаются только ужины с выбранным блюдом,
+// поэтому, сколько бы гарниров ни было у мяса, шанс супа или салата от этого не меньше.
+// Случайные выборы записываются в trace (nil - без записи). false - подходящих ужинов нет.
+func (d *Dinner) chooseDinner(candidates [][]models.Food, foods []models.Food, pairings models.Pairings, strategy Strategy, profile Profile, full bool, trace *models.Trace) ([]models.Food, bool) {
+	if len(candidates) == 0 {
+		return nil, false
+	}
+	main := func(food models.Food) bool {
+		return !full || food.Category == models.Meat || food.Category == models.SideDish
+	}
+	if firsts := dinnerFoods(candidates, foods, main); len(firsts) > 0 {
+		first, ok := d.chooseFood(firsts, strategy, profile, models.DrawDish, trace)
+		if !ok {
+			return nil, false
+		}
+		candidates = withFood(candidates, first)
+		pairs := dinnerFoods(candidates, foods, func(food models.Food) bool {
+			return food.Category == models.Meat && first.Category == models.SideDish ||
+				food.Category == models.SideDish && first.Category == models.Meat
+		})
+		if dinner := d.completeDinner([]models.Food{first}, pairs, pairings, trace); len(dinner) > 1 {
+			candidates = withFood(candidates, dinner[1])
+		}
+	}
+	if full {
+		for _, course := range extraCourses {
+			pool := dinnerFoods(candidates, foods, func(food models.Food) bool { return food.Category == course.category })
+			if len(pool) == 0 {
+				continue
+			}
+			food, ok := d.chooseFood(pool, strategy, profile, course.draw, trace)
+			if !ok {
+				return nil, false
+			}
+			candidates = withFood(candidates, food)
+		}
+	}
+	return candidates[0], true
+}
+
+// dinnerFoods отдает блюда foods, подходящие под keep и входящие хотя бы в один ужин dinners.
+// Повторы блюда в foods (см. calendarFoods) сохраняются.
+func dinnerFoods(dinners [][]models.Food, foods []models.Food, keep func(models.Food) bool) []models.Food {
+	ids := make(map[int64]struct{})
+	for _, dinner := range dinners {
+		for _, food := range dinner {
+			ids[food.ID] = struct{}{}
+		}
+	}
+	return slices.DeleteFunc(slices.Clone(foods), func(food models.Food) bool {
+		_, ok := ids[food.ID]
+		return !ok || !keep(food)
+	})
+}
+
+// withFood отдает ужины dinners, в которые входит блюдо food
+func withFood(dinners [][]models.Food, food models.Food) [][]models.Food {
+	return slices.DeleteFunc(slices.Clone(dinners), func(dinner []models.Food) bool {
+		return !slices.ContainsFunc(dinner, func(f models.Food) bool { return f.ID == food.ID })
+	})
+}
+
+// dinnerWeight отдает вес ужина: для мяса с гарниром - вес их сочетания
+func dinnerWeight(dinner []models.Food, pairings models.Pairings) int {
+	if len(dinner) < 2 {
+		return models.PairAllowed
+	}
+	return pairings.Weight(dinner[0], dinner[1])
+}
+
+// allDinners перечисляет все возможные ужины: суп, салат или мясо с гарниром
+// (мясо без гарнира, если гарниров нет или все сочетания с ним запрещены).
+// Каждое сочетание перечисляется один раз, мясо идет первым. В отличие от selectDinner
+// гарнир без мяса ужином не считается.
+func allDinners(foods []models.Food, pairings models.Pairings) [][]models.Food {
+	sideDishes := GetSideDishes(&foods)
+	dinners := make([][]models.Food, 0, len(foods))
+	for _, food := range foods {
+		switch food.Category {
+		case models.Soup, models.Salad:
+			dinners = append(dinners, []models.Food{food})
+		case models.Meat:
+			paired := false
+			for _, side := range sideDishes {
+				if pairings.Weight(food, side) != models.PairForbidden {
+					dinners = append(dinners, []models.Food{food, side})
+					paired = true
+				}
+			}
+			if !paired {
+				dinners = append(dinners, []models.Food{food})
+			}
+		}
+	}
+	return dinners
+}
+
+// selectDinner выбирает блюдо стратегией strategy и, если нужно, дополняет его гарниром или мясом.
+// Пара выбирается с учетом веса сочетания, запрещенные сочетания не предлагаются.
+// Случайные выборы записываются в trace (nil - без записи).
+func (d *Dinner) selectDinner(foods []models.Food, pairings models.Pairings, strategy Strategy, profile Profile, trace *models.Trace) []models.Food {
+	first, ok := d.chooseFood(foods, strategy, profile, models.DrawDish, trace)
+	if !ok {
+		return nil
+	}
 	food := make([]models.Food, 1, 2)
-	food[0] = foods[rndPos]
+	food[0] = first
 
 	// В зависимости от типа блюда отдаем 1 блюдо или ищем гранир к мясу
 	switch food[0].Category {
 	case models.Soup:
-		return food, nil
+		return food
 	case models.Salad:
-		return food, nil
+		return food
 	case models.Meat:
-		sideDishes := GetSideDishes(&foods)
-		if len(sideDishes) == 0 {
-			return food, nil
-		}
-		food = append(food, sideDishes[rand.IntN(len(sideDishes))])
-		return food, nil
+		return d.completeDinner(food, GetSideDishes(&foods), pairings, trace)
 	case models.SideDish:
-		meats := GetMeats(&foods)
-		if len(meats) == 0 {
-			return food, nil
-		}
-		food = append(food, meats[rand.IntN(len(meats))])
-		return food, nil
+		return d.completeDinner(food, GetMeats(&foods), pairings, trace)
+	}
+	return nil
+}
+
+// chooseFood выбирает блюдо из foods стратегией strategy и записывает выбор draw в trace.
+// Каждое блюдо - вариант с весом 1, блюдо может встречаться несколько раз (см. calendarFoods).
+func (d *Dinner) chooseFood(foods []models.Food, strategy Strategy, profile Profile, draw string, trace *models.Trace) (models.Food, bool) {
+	options := make([]Option, 0, len(foods))
+	for _, food := range foods {
+		options = append(options, Option{Foods: []models.Food{food}, Weight: 1})
+	}
+	choice := strategy.Choose(options, profile, d.intN)
+	if choice.Index < 0 {
+		return models.Food{}, false
+	}
+	trace.AddDraw(models.TraceDraw{
+		Name:    draw,
+		Chosen:  []models.Food{foods[choice.Index]},
+		Weight:  choice.Weight,
+		Total:   choice.Total,
+		Options: len(foods),
+		Roll:    choice.Roll,
+	})
+	return foods[choice.Index], true
+}
+
+// completeDinner дополняет блюдо food парой из candidates с учетом весов сочетаний.
+// Если подходящей пары нет, блюдо остается одно.
+func (d *Dinner) completeDinner(food []models.Food, candidates []models.Food, pairings models.Pairings, trace *models.Trace) []models.Food {
+	options := make([]Option, 0, len(candidates))
+	weights := make([]int, 0, len(candidates))
+	for _, candidate := range candidates {
+		weight := pairings.Weight(food[0], candidate)
+		options = append(options, Option{Foods: []models.Food{candidate}, Weight: weight})
+		weights = append(weights, weight)
+	}
+	choice := weightedChoice(options, weights, d.intN)
+	if choice.Index < 0 {
+		return food
 	}
-	return nil, services.ErrEmptyFood
+	trace.AddDraw(models.TraceDraw{
+		Name:    models.DrawPair,
+		Chosen:  []models.Food{candidates[choice.Index]},
+		Weight:  choice.Weight,
+		Total:   choice.Total,
+		Options: len(candidates),
+		Roll:    choice.Roll,
+	})
+	return append(food, candidates[choice.Index])
 }
 
 // GetSideDishes ищет гарнир к мясу
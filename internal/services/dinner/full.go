@@ -0,0 +1,101 @@
+package dinnerservice
+
+import (
+	"dinner/internal/domain/models"
+	"slices"
+)
+
+// Подачи ужина из нескольких блюд кроме основного: категория и название выбора в trace
+var extraCourses = []struct {
+	category models.FootCategory
+	draw     string
+}{
+	{models.Salad, models.DrawStarter},
+	{models.Soup, models.DrawSoup},
+}
+
+// selectFullDinner выбирает ужин из нескольких подач: основное блюдо с гарниром
+// (см. selectDinner), салат на закуску и суп. Подача, для которой не осталось блюд,
+// пропускается. Основное блюдо идет первым, порядок подачи задает форматтер.
+func (d *Dinner) selectFullDinner(foods []models.Food, pairings models.Pairings, strategy Strategy, profile Profile, trace *models.Trace) []models.Food {
+	var dinner []models.Food
+	if mains := mainFoods(foods); len(mains) > 0 {
+		dinner = d.selectDinner(mains, pairings, strategy, profile, trace)
+	}
+	for _, course := range extraCourses {
+		pool := categoryFoods(foods, course.category)
+		if len(pool) == 0 {
+			continue
+		}
+		if food, ok := d.chooseFood(pool, strategy, profile, course.draw, trace); ok {
+			dinner = append(dinner, food)
+		}
+	}
+	return dinner
+}
+
+// Наибольшее количество ужинов из нескольких подач, которое составляется на одну подачу.
+// Если сочетаний больше, составляется случайная выборка такого размера.
+const maxFullDinners = 2000
+
+// fullDinners перечисляет возможные ужины из нескольких подач так же,
+// как их составляет selectFullDinner. Подачи добавляются по одной, и ужины,
+// которые уже не укладываются в верхнюю границу kcal, отбрасываются сразу.
+// Если сочетаний с очередной подачей больше maxFullDinners, вместо всех сочетаний
+// берется случайная выборка: каждое сочетание попадает в нее с той же вероятностью.
+func (d *Dinner) fullDinners(foods []models.Food, pairings models.Pairings, kcal models.KcalRange) [][]models.Food {
+	dinners := slices.DeleteFunc(allDinners(mainFoods(foods), pairings), func(dinner []models.Food) bool {
+		return !underKcal(dinner, kcal)
+	})
+	if len(mainFoods(foods)) == 0 {
+		dinners = [][]models.Food{nil}
+	}
+	for _, course := range extraCourses {
+		// Повторы блюда (см. calendarFoods) остаются, чтобы сохранить его вес
+		pool := categoryFoods(foods, course.category)
+		if len(pool) == 0 || len(dinners) == 0 {
+			continue
+		}
+		var res [][]models.Food
+		if len(dinners)*len(pool) <= maxFullDinners {
+			res = make([][]models.Food, 0, len(dinners)*len(pool))
+			for _, dinner := range dinners {
+				for _, food := range pool {
+					res = append(res, append(slices.Clip(dinner), food))
+				}
+			}
+		} else {
+			res = make([][]models.Food, 0, maxFullDinners)
+			for range maxFullDinners {
+				dinner := dinners[d.intN(len(dinners))]
+				res = append(res, append(slices.Clip(dinner), pool[d.intN(len(pool))]))
+			}
+		}
+		dinners = slices.DeleteFunc(res, func(dinner []models.Food) bool { return !underKcal(dinner, kcal) })
+	}
+	return slices.DeleteFunc(dinners, func(dinner []models.Food) bool { return len(dinner) == 0 })
+}
+
+// underKcal сообщает, что часть ужина dinner еще может уложиться в диапазон kcal:
+// калорийность всех блюд известна и не больше верхней границы. Без диапазона подходит любой ужин.
+func underKcal(dinner []models.Food, kcal models.KcalRange) bool {
+	if kcal.IsZero() || len(dinner) == 0 {
+		return true
+	}
+	total, ok := models.TotalNutrition(dinner)
+	return ok && (kcal.Max == 0 || total.Kcal <= kcal.Max)
+}
+
+// mainFoods отдает блюда для основной подачи: мясо и гарниры
+func mainFoods(foods []models.Food) []models.Food {
+	return slices.DeleteFunc(slices.Clone(foods), func(food models.Food) bool {
+		return food.Category != models.Meat && food.Category != models.SideDish
+	})
+}
+
+// categoryFoods отдает блюда категории category
+func categoryFoods(foods []models.Food, category models.FootCategory) []models.Food {
+	return slices.DeleteFunc(slices.Clone(foods), func(food models.Food) bool {
+		return food.Category != category
+	})
+}
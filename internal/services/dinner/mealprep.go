@@ -0,0 +1,220 @@
+package dinnerservice
+
+import (
+	"cmp"
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/stem"
+	"dinner/internal/services"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// Наибольшее количество ужинов в заготовке
+const MaxPrepDinners = 7
+
+// Наибольшее количество порций каждого блюда в заготовке
+const MaxPrepPortions = 20
+
+// Заготовка по умолчанию: ужинов и порций каждого блюда
+const (
+	DefaultPrepDinners  = 3
+	DefaultPrepPortions = 4
+)
+
+// Порядок приготовления категорий: суп варится дольше всех и не требует внимания,
+// салат собирается последним, чтобы дольше оставался свежим
+var cookOrder = map[models.FootCategory]int{
+	models.Soup:     0,
+	models.Meat:     1,
+	models.SideDish: 2,
+	models.Salad:    3,
+}
+
+// GetMealPrep подбирает dinners ужинов по portions порций для заготовки на несколько дней.
+// В отличие от GetPlan ужины подбираются не на разнообразие, а на общие ингредиенты:
+// первый ужин выбирается стратегией пользователя, каждый следующий - среди ужинов
+// с наибольшим числом ингредиентов, уже вошедших в заготовку (из равных - случайно
+// с учетом сочетаний мяса и гарнира). Блюда в заготовке не повторяются.
+// Учитываются ограничения opts.Exclude и календарь на дату opts.Date, калории не учитываются.
+// Заготовка учитывается в лимите запросов как один запрос.
+func (d *Dinner) GetMealPrep(userId int64, dinners int, portions int, opts Options) (models.MealPrep, error) {
+	const op = "Dinner.GetMealPrep"
+
+	log := d.log.With(
+		slog.String("op", op),
+	)
+	if dinners < 1 || dinners > MaxPrepDinners || portions < 1 || portions > MaxPrepPortions {
+		return models.MealPrep{}, fmt.Errorf("%s: %w: %d x %d", op, services.ErrInvalidMealPrep, dinners, portions)
+	}
+	limit, err := d.historyProvider.IsLimit(userId)
+	if err != nil {
+		return models.MealPrep{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if !limit {
+		return models.MealPrep{}, fmt.Errorf("%s: %w", op, services.ErrAttemptLimitExceeded)
+	}
+
+	foods, err := d.foodProvider.GetFoods()
+	if err != nil {
+		return models.MealPrep{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(foods) == 0 {
+		return models.MealPrep{}, fmt.Errorf("%s: %w", op, services.ErrEmptyFood)
+	}
+	strategy, profile, err := d.strategy(userId, foods, opts)
+	if err != nil {
+		return models.MealPrep{}, fmt.Errorf("%s: %w", op, err)
+	}
+	pairings, err := d.pairings()
+	if err != nil {
+		return models.MealPrep{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Календарь повторяет блюда предпочтительного дня недели, для заготовки нужен каждый один раз
+	pool := calendarFoods(opts.allowed(foods), opts.Date, nil)
+	slices.SortStableFunc(pool, func(a, b models.Food) int { return cmp.Compare(a.ID, b.ID) })
+	pool = slices.CompactFunc(pool, func(a, b models.Food) bool { return a.ID == b.ID })
+	candidates := allDinners(pool, pairings)
+
+	prep := models.MealPrep{Portions: portions}
+	for len(prep.Dinners) < dinners {
+		dinner, ok := d.nextPrepDinner(candidates, prep.Dinners, pairings, strategy, profile)
+		if !ok {
+			break
+		}
+		prep.Dinners = append(prep.Dinners, dinner)
+	}
+	if len(prep.Dinners) == 0 {
+		return models.MealPrep{}, fmt.Errorf("%s: %w", op, services.ErrNoMatchingDinner)
+	}
+	prep.CookOrder = prepCookOrder(prep.Dinners)
+	prep.Shopping = shoppingList(prep.CookOrder, portions)
+
+	//Сохранение запроса пользователя и блюд заготовки в истории
+	if err := d.historyProvider.SavePlanRequest(userId, prep.CookOrder); err != nil {
+		return models.MealPrep{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := d.saveQueue(userId, profile, prep.CookOrder); err != nil {
+		return models.MealPrep{}, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("meal prep save request", slog.Int("dinners", len(prep.Dinners)), slog.Int("portions", portions))
+
+	return prep, nil
+}
+
+// nextPrepDinner выбирает следующий ужин заготовки среди candidates без блюд из chosen.
+// Первый ужин выбирает стратегия, следующие - с наибольшим числом общих с chosen ингредиентов.
+func (d *Dinner) nextPrepDinner(candidates [][]models.Food, chosen [][]models.Food, pairings models.Pairings, strategy Strategy, profile Profile) ([]models.Food, bool) {
+	used := make(map[int64]struct{})
+	have := make(map[string]struct{})
+	for _, dinner := range chosen {
+		for _, food := range dinner {
+			used[food.ID] = struct{}{}
+			for _, ingredient := range food.Ingredients {
+				have[ingredientKey(ingredient)] = struct{}{}
+			}
+		}
+	}
+
+	options := make([]Option, 0, len(candidates))
+	scores := make([]int, 0, len(candidates))
+	best := 0
+	for _, dinner := range candidates {
+		weight := dinnerWeight(dinner, pairings)
+		score := 0
+		for _, food := range dinner {
+			if _, ok := used[food.ID]; ok {
+				weight = 0
+			}
+		}
+		for _, key := range dinnerIngredients(dinner) {
+			if _, ok := have[key]; ok {
+				score++
+			}
+		}
+		if weight > 0 {
+			best = max(best, score)
+		}
+		options = append(options, Option{Foods: dinner, Weight: weight})
+		scores = append(scores, score)
+	}
+	if len(chosen) == 0 {
+		choice := strategy.Choose(options, profile, d.intN)
+		if choice.Index < 0 {
+			return nil, false
+		}
+		return candidates[choice.Index], true
+	}
+	for i := range options {
+		if scores[i] < best {
+			options[i].Weight = 0
+		}
+	}
+	choice := Random{}.Choose(options, profile, d.intN)
+	if choice.Index < 0 {
+		return nil, false
+	}
+	return candidates[choice.Index], true
+}
+
+// prepCookOrder отдает блюда заготовки в порядке приготовления (см. cookOrder).
+// Блюда одной категории готовятся в порядке выбора ужинов, соседние ужины
+// чаще всего делят ингредиенты.
+func prepCookOrder(dinners [][]models.Food) []models.Food {
+	foods := slices.Concat(dinners...)
+	slices.SortStableFunc(foods, func(a, b models.Food) int {
+		return cmp.Compare(cookOrder[a.Category], cookOrder[b.Category])
+	})
+	return foods
+}
+
+// shoppingList собирает ингредиенты блюд foods по portions порций в список покупок.
+// Одинаковые с точностью до окончания ингредиенты ("курица", "курицу") объединяются.
+func shoppingList(foods []models.Food, portions int) []models.ShoppingItem {
+	items := []models.ShoppingItem{}
+	positions := make(map[string]int)
+	for _, food := range foods {
+		seen := make(map[string]struct{})
+		for _, ingredient := range food.Ingredients {
+			key := ingredientKey(ingredient)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			if i, ok := positions[key]; ok {
+				items[i].Dishes++
+				items[i].Portions += portions
+				continue
+			}
+			positions[key] = len(items)
+			items = append(items, models.ShoppingItem{Ingredient: ingredient, Dishes: 1, Portions: portions})
+		}
+	}
+	slices.SortStableFunc(items, func(a, b models.ShoppingItem) int {
+		if a.Dishes != b.Dishes {
+			return b.Dishes - a.Dishes
+		}
+		return strings.Compare(a.Ingredient, b.Ingredient)
+	})
+	return items
+}
+
+// dinnerIngredients отдает ингредиенты ужина без повторов (см. ingredientKey)
+func dinnerIngredients(dinner []models.Food) []string {
+	keys := []string{}
+	for _, food := range dinner {
+		for _, ingredient := range food.Ingredients {
+			if key := ingredientKey(ingredient); !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// ingredientKey отдает ингредиент без учета регистра и окончаний
+func ingredientKey(ingredient string) string {
+	return strings.Join(stem.Phrase(ingredient), " ")
+}
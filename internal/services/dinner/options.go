@@ -0,0 +1,134 @@
+package dinnerservice
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Верхняя граница калорий легкого ужина
+const LightKcal = 500
+
+// Дней в неделе: недельный бюджет делится поровну между ужинами
+const DaysPerWeek = 7
+
+// Дополнительные условия подбора ужина
+type Options struct {
+	// Диапазон калорий ужина, пустой - без ограничения
+	Kcal models.KcalRange
+	// Легкий ужин: не больше LightKcal калорий
+	Light bool
+	// Дата ужина в часовом поясе пользователя для учета сезона и дня недели,
+	// нулевая - без учета календаря
+	Date time.Time
+	// Названия блюд, теги и ингредиенты, которые не предлагаются
+	// (ограничения в питании пользователя или всей семьи)
+	Exclude []string
+	// Ужин из нескольких подач: закуска, суп и основное блюдо с гарниром
+	Full bool
+	// Стратегия выбора (см. StrategyByName), пустая - случайный выбор
+	Mode string
+	// Бюджет на ужины за неделю в рублях, 0 - без бюджета
+	Budget int
+	// Дешевый ужин: не дороже бюджета одного ужина, без бюджета - не дороже
+	// медианной стоимости среди ужинов с известной стоимостью
+	Cheap bool
+	// Цены ингредиентов для расчета стоимости ужина
+	Prices models.Prices
+}
+
+// allowed убирает из foods блюда, попадающие под ограничения Exclude
+func (o Options) allowed(foods []models.Food) []models.Food {
+	if len(o.Exclude) == 0 {
+		return foods
+	}
+	return slices.DeleteFunc(slices.Clone(foods), func(food models.Food) bool {
+		return slices.ContainsFunc(o.Exclude, food.Mentions)
+	})
+}
+
+// budgeted сообщает, что ужин из foods подбирается с учетом стоимости.
+// Бюджет без Cheap учитывается, только если стоимость хотя бы одного блюда известна:
+// иначе ни один ужин не укладывается в бюджет и подходят все.
+func (o Options) budgeted(foods []models.Food) bool {
+	if o.Cheap {
+		return true
+	}
+	return o.Budget > 0 && slices.ContainsFunc(foods, func(food models.Food) bool {
+		_, ok := o.Prices.FoodCost(food)
+		return ok
+	})
+}
+
+// affordable отбирает из candidates ужины не дороже бюджета одного ужина
+// (Budget / DaysPerWeek), а для дешевого ужина без бюджета - не дороже медианной стоимости.
+// Ужины с неизвестной стоимостью не проходят отбор. Если в бюджет не укладывается
+// ни один ужин, без Cheap остаются все: бюджет только предпочтение.
+func (o Options) affordable(candidates [][]models.Food) [][]models.Food {
+	limit := o.Budget / DaysPerWeek
+	if o.Budget == 0 {
+		costs := make([]int, 0, len(candidates))
+		for _, dinner := range candidates {
+			if cost, ok := o.Prices.DinnerCost(dinner); ok {
+				costs = append(costs, cost)
+			}
+		}
+		if len(costs) == 0 {
+			return nil
+		}
+		slices.Sort(costs)
+		limit = costs[(len(costs)-1)/2]
+	}
+	res := slices.DeleteFunc(slices.Clone(candidates), func(dinner []models.Food) bool {
+		cost, ok := o.Prices.DinnerCost(dinner)
+		return !ok || cost > limit
+	})
+	if len(res) == 0 && !o.Cheap {
+		return candidates
+	}
+	return res
+}
+
+// kcal отдает итоговый диапазон калорий с учетом легкого ужина
+func (o Options) kcal() models.KcalRange {
+	kcal := o.Kcal
+	if o.Light && (kcal.Max == 0 || kcal.Max > LightKcal) {
+		kcal.Max = LightKcal
+	}
+	return kcal
+}
+
+// ParseKcalRange разбирает диапазон калорий: "400-700", "-600" (не больше),
+// "400-" (не меньше) или "600" (не больше). "off" и пустая строка снимают ограничение.
+func ParseKcalRange(value string) (models.KcalRange, error) {
+	const op = "dinnerservice.ParseKcalRange"
+
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || value == "off" {
+		return models.KcalRange{}, nil
+	}
+	minValue, maxValue, found := strings.Cut(value, "-")
+	if !found {
+		minValue, maxValue = "", value
+	}
+	var target models.KcalRange
+	var err error
+	if minValue = strings.TrimSpace(minValue); minValue != "" {
+		if target.Min, err = strconv.ParseFloat(minValue, 64); err != nil {
+			return models.KcalRange{}, fmt.Errorf("%s: %w: %q", op, services.ErrInvalidKcalTarget, value)
+		}
+	}
+	if maxValue = strings.TrimSpace(maxValue); maxValue != "" {
+		if target.Max, err = strconv.ParseFloat(maxValue, 64); err != nil {
+			return models.KcalRange{}, fmt.Errorf("%s: %w: %q", op, services.ErrInvalidKcalTarget, value)
+		}
+	}
+	if target.Min < 0 || target.Max < 0 || (target.Max != 0 && target.Min > target.Max) || target.IsZero() {
+		return models.KcalRange{}, fmt.Errorf("%s: %w: %q", op, services.ErrInvalidKcalTarget, value)
+	}
+	return target, nil
+}
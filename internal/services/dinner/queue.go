@@ -0,0 +1,97 @@
+package dinnerservice
+
+import (
+	"dinner/internal/domain/models"
+	"slices"
+)
+
+// Shuffle предлагает все блюда каталога по разу в случайном порядке.
+// Порядок хранится в очереди пользователя (Profile.Queue), которую сервис
+// сверяет с каталогом перед выбором (см. syncQueue) и сохраняет после.
+// Если ни одно из оставшихся в круге блюд сейчас не подходит
+// (не сезон, ограничения, калории), выбор случайный.
+type Shuffle struct{}
+
+func (Shuffle) Name() string       { return StrategyShuffle }
+func (Shuffle) NeedsProfile() bool { return false }
+
+func (Shuffle) Choose(options []Option, profile Profile, intN func(n int) int) Choice {
+	positions := make(map[int64]int, len(profile.Queue))
+	for i, item := range profile.Queue {
+		if _, ok := positions[item.FoodID]; !ok && !item.Served {
+			positions[item.FoodID] = i
+		}
+	}
+	next, best := int64(0), -1
+	for _, option := range options {
+		if option.Weight <= 0 || len(option.Foods) == 0 {
+			continue
+		}
+		if pos, ok := positions[option.Foods[0].ID]; ok && (best < 0 || pos < best) {
+			next, best = option.Foods[0].ID, pos
+		}
+	}
+	if best < 0 {
+		return Random{}.Choose(options, profile, intN)
+	}
+	// Блюдо может встречаться в нескольких вариантах (мясо с разными гарнирами)
+	weights := make([]int, 0, len(options))
+	for _, option := range options {
+		weight := 0
+		if len(option.Foods) > 0 && option.Foods[0].ID == next {
+			weight = option.Weight
+		}
+		weights = append(weights, weight)
+	}
+	return weightedChoice(options, weights, intN)
+}
+
+// syncQueue сверяет очередь блюд с каталогом foods.
+// Удаленные из каталога блюда убираются из очереди, новые вставляются в случайные
+// места среди еще не предложенных, чтобы попасть в текущий круг.
+// Когда все блюда предложены, очередь перемешивается заново.
+func syncQueue(queue models.FoodQueue, foods []models.Food, intN func(n int) int) models.FoodQueue {
+	catalog := make(map[int64]bool, len(foods))
+	for _, food := range foods {
+		catalog[food.ID] = true
+	}
+	res := make(models.FoodQueue, 0, len(foods))
+	known := make(map[int64]bool, len(foods))
+	for _, item := range queue {
+		if catalog[item.FoodID] && !known[item.FoodID] {
+			res = append(res, item)
+			known[item.FoodID] = true
+		}
+	}
+	if res.Remaining() == 0 {
+		return newQueue(foods, intN)
+	}
+	// Новые блюда - не раньше первого еще не предложенного
+	first := slices.IndexFunc(res, func(item models.QueueItem) bool { return !item.Served })
+	for _, food := range foods {
+		if known[food.ID] {
+			continue
+		}
+		pos := first + intN(len(res)-first+1)
+		res = slices.Insert(res, pos, models.QueueItem{FoodID: food.ID})
+		known[food.ID] = true
+	}
+	return res
+}
+
+// newQueue перемешивает блюда каталога foods в новую очередь
+func newQueue(foods []models.Food, intN func(n int) int) models.FoodQueue {
+	queue := make(models.FoodQueue, 0, len(foods))
+	known := make(map[int64]bool, len(foods))
+	for _, food := range foods {
+		if !known[food.ID] {
+			queue = append(queue, models.QueueItem{FoodID: food.ID})
+			known[food.ID] = true
+		}
+	}
+	for i := len(queue) - 1; i > 0; i-- {
+		j := intN(i + 1)
+		queue[i], queue[j] = queue[j], queue[i]
+	}
+	return queue
+}
@@ -0,0 +1,265 @@
+package dinnerservice
+
+import (
+	"dinner/internal/domain/models"
+	"slices"
+	"time"
+)
+
+// Названия стратегий выбора ужина
+const (
+	// Равновероятный выбор
+	StrategyRandom = "random"
+	// Выбор с учетом оценок пользователя
+	StrategyRating = "rating"
+	// Сначала блюда, которые давно не предлагались
+	StrategyFresh = "fresh"
+	// Блюда каталога по очереди
+	StrategyRoundRobin = "roundrobin"
+	// Все блюда каталога по разу в случайном порядке
+	StrategyShuffle = "shuffle"
+)
+
+// Оценка блюда, которое пользователь не оценивал
+const DefaultRating = 3
+
+// Strategy выбирает ужин среди вариантов, отобранных сервисом.
+// Проверка лимита, отбор по условиям, подбор пары к мясу и сохранение истории
+// общие для всех стратегий и остаются в Dinner.
+type Strategy interface {
+	// Name отдает название стратегии для команды /mode
+	Name() string
+	// NeedsProfile сообщает, нужны ли стратегии оценки и история пользователя
+	NeedsProfile() bool
+	// Choose выбирает вариант из options.
+	// intN - источник случайных чисел сервиса, отдает число из [0, n).
+	Choose(options []Option, profile Profile, intN func(n int) int) Choice
+}
+
+// Вариант выбора: блюдо или ужин целиком
+type Option struct {
+	Foods []models.Food
+	// Вес варианта, назначенный сервисом (сочетание мяса и гарнира, день недели).
+	// Варианты с нулевым весом не выбираются.
+	Weight int
+}
+
+// Результат выбора стратегии
+type Choice struct {
+	// Индекс выбранного варианта, -1 - выбрать не из чего
+	Index int
+	// Вес выбранного блюда и сумма весов всех вариантов: шанс выбора Weight из Total
+	Weight int
+	Total  int
+	// Выпавшее случайное число
+	Roll int
+}
+
+// Оценки и история пользователя, на которые опираются стратегии
+type Profile struct {
+	// Оценки блюд от 1 до 5 по id блюда
+	Ratings map[int64]int
+	// Когда блюдо предлагалось последний раз, по id блюда
+	LastServed map[int64]time.Time
+	// id первого блюда последнего ужина, 0 - ужинов еще не было
+	LastDish int64
+	// Очередь блюд для Shuffle, nil - у стратегии нет очереди
+	Queue models.FoodQueue
+}
+
+// NewProfile собирает профиль по оценкам и истории запросов пользователя
+func NewProfile(ratings map[int64]int, history []models.HistoryEntry) Profile {
+	profile := Profile{
+		Ratings:    ratings,
+		LastServed: make(map[int64]time.Time),
+	}
+	for _, entry := range history {
+		for _, food := range entry.Foods {
+			if last, ok := profile.LastServed[food.ID]; !ok || entry.Time.After(last) {
+				profile.LastServed[food.ID] = entry.Time
+			}
+		}
+		if len(entry.Foods) > 0 {
+			profile.LastDish = entry.Foods[0].ID
+		}
+	}
+	return profile
+}
+
+// rating отдает оценку блюда food или DefaultRating, если оценки нет
+func (p Profile) rating(food models.Food) int {
+	if rating, ok := p.Ratings[food.ID]; ok {
+		return rating
+	}
+	return DefaultRating
+}
+
+// lastServed отдает, когда последний раз предлагалось одно из блюд варианта.
+// Нулевое время - ни одно из блюд не предлагалось.
+func (p Profile) lastServed(option Option) time.Time {
+	var last time.Time
+	for _, food := range option.Foods {
+		if t := p.LastServed[food.ID]; t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
+
+// Стратегии по названию
+var strategies = map[string]Strategy{
+	StrategyRandom:     Random{},
+	StrategyRating:     Rating{},
+	StrategyFresh:      Fresh{},
+	StrategyRoundRobin: RoundRobin{},
+	StrategyShuffle:    Shuffle{},
+}
+
+// StrategyByName отдает стратегию по названию.
+// Пустое название - стратегия по умолчанию (Random).
+func StrategyByName(name string) (Strategy, bool) {
+	if name == "" {
+		return Random{}, true
+	}
+	strategy, ok := strategies[name]
+	return strategy, ok
+}
+
+// StrategyNames отдает названия всех стратегий, первой - стратегию по умолчанию
+func StrategyNames() []string {
+	return []string{StrategyRandom, StrategyRating, StrategyFresh, StrategyRoundRobin, StrategyShuffle}
+}
+
+// Random выбирает вариант случайно с учетом веса варианта
+type Random struct{}
+
+func (Random) Name() string       { return StrategyRandom }
+func (Random) NeedsProfile() bool { return false }
+
+func (Random) Choose(options []Option, _ Profile, intN func(n int) int) Choice {
+	weights := make([]int, 0, len(options))
+	for _, option := range options {
+		weights = append(weights, option.Weight)
+	}
+	return weightedChoice(options, weights, intN)
+}
+
+// Rating выбирает вариант случайно с весом, умноженным на оценку первого блюда:
+// блюдо с оценкой 5 выпадает в пять раз чаще блюда с оценкой 1.
+// Неоцененные блюда считаются оцененными на DefaultRating.
+type Rating struct{}
+
+func (Rating) Name() string       { return StrategyRating }
+func (Rating) NeedsProfile() bool { return true }
+
+func (Rating) Choose(options []Option, profile Profile, intN func(n int) int) Choice {
+	weights := make([]int, 0, len(options))
+	for _, option := range options {
+		weight := option.Weight
+		if len(option.Foods) > 0 {
+			weight *= profile.rating(option.Foods[0])
+		}
+		weights = append(weights, weight)
+	}
+	return weightedChoice(options, weights, intN)
+}
+
+// Fresh выбирает вариант, блюда которого дольше всего не предлагались.
+// Из равных вариантов (например, ни разу не предлагавшихся) выбирает случайно.
+type Fresh struct{}
+
+func (Fresh) Name() string       { return StrategyFresh }
+func (Fresh) NeedsProfile() bool { return true }
+
+func (Fresh) Choose(options []Option, profile Profile, intN func(n int) int) Choice {
+	var oldest time.Time
+	found := false
+	for _, option := range options {
+		if option.Weight <= 0 {
+			continue
+		}
+		if last := profile.lastServed(option); !found || last.Before(oldest) {
+			oldest, found = last, true
+		}
+	}
+	weights := make([]int, 0, len(options))
+	for _, option := range options {
+		weight := 0
+		if found && option.Weight > 0 && profile.lastServed(option).Equal(oldest) {
+			weight = 1
+		}
+		weights = append(weights, weight)
+	}
+	return weightedChoice(options, weights, intN)
+}
+
+// RoundRobin предлагает блюда каталога по очереди в порядке id:
+// следующее после первого блюда последнего ужина, после последнего - снова первое.
+// Случайные числа не использует.
+type RoundRobin struct{}
+
+func (RoundRobin) Name() string       { return StrategyRoundRobin }
+func (RoundRobin) NeedsProfile() bool { return true }
+
+func (RoundRobin) Choose(options []Option, profile Profile, _ func(n int) int) Choice {
+	next, first := -1, -1
+	for i, option := range options {
+		if option.Weight <= 0 || len(option.Foods) == 0 {
+			continue
+		}
+		id := option.Foods[0].ID
+		if first < 0 || id < options[first].Foods[0].ID {
+			first = i
+		}
+		if id > profile.LastDish && (next < 0 || id < options[next].Foods[0].ID) {
+			next = i
+		}
+	}
+	if next < 0 {
+		next = first
+	}
+	if next < 0 {
+		return Choice{Index: -1}
+	}
+	return Choice{Index: next, Weight: 1, Total: 1}
+}
+
+// weightedChoice выбирает вариант с вероятностью, пропорциональной весу.
+// Вес выбора - сумма весов всех вариантов с теми же блюдами,
+// так как одно блюдо может встречаться среди вариантов несколько раз (см. calendarFoods).
+func weightedChoice(options []Option, weights []int, intN func(n int) int) Choice {
+	total := 0
+	for _, weight := range weights {
+		total += weight
+	}
+	if total <= 0 {
+		return Choice{Index: -1, Total: total}
+	}
+	roll := intN(total)
+	n := roll
+	index := -1
+	for i, weight := range weights {
+		if n < weight {
+			index = i
+			break
+		}
+		n -= weight
+	}
+	if index < 0 {
+		return Choice{Index: -1, Total: total, Roll: roll}
+	}
+	weight := 0
+	for i, option := range options {
+		if sameFoods(option.Foods, options[index].Foods) {
+			weight += weights[i]
+		}
+	}
+	return Choice{Index: index, Weight: weight, Total: total, Roll: roll}
+}
+
+// sameFoods сравнивает наборы блюд по id и названию
+func sameFoods(a, b []models.Food) bool {
+	return slices.EqualFunc(a, b, func(x, y models.Food) bool {
+		return x.ID == y.ID && x.Name == y.Name
+	})
+}
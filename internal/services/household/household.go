@@ -0,0 +1,239 @@
+// Сервис семей: общий аккаунт нескольких пользователей с общей историей,
+// лимитом запросов и объединенными ограничениями в питании.
+package householdservice
+
+import (
+	"crypto/rand"
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	"dinner/internal/storages"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// Префикс параметра ссылки-приглашения: /start join_<код>
+const JoinPrefix = "join_"
+
+// Кодирование кода приглашения: только символы, допустимые в параметре /start
+var codeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type Households struct {
+	log     *slog.Logger
+	storage HouseholdStorage
+}
+
+// Доступ к семьям и ограничениям в питании пользователей
+type HouseholdStorage interface {
+	GetHousehold(userId int64) (models.Household, error)
+	GetHouseholdByCode(code string) (models.Household, error)
+	CreateHousehold(ownerId int64, code string) (int64, error)
+	AddHouseholdMember(householdId int64, userId int64) error
+	RemoveHouseholdMember(householdId int64, userId int64) error
+	SetHouseholdCode(householdId int64, code string) error
+	DeleteHousehold(id int64) error
+	GetRestrictions(userId int64) ([]string, error)
+	SetRestrictions(userId int64, items []string) error
+}
+
+// New - конструктор сервиса
+func New(log *slog.Logger, storage HouseholdStorage) *Households {
+	return &Households{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// Get отдает семью юзера userId
+func (h *Households) Get(userId int64) (models.Household, error) {
+	const op = "Households.Get"
+
+	household, err := h.storage.GetHousehold(userId)
+	if err != nil {
+		return models.Household{}, fmt.Errorf("%s: %w", op, householdError(err))
+	}
+	return household, nil
+}
+
+// Create создает семью, владельцем которой становится юзер userId
+func (h *Households) Create(userId int64) (models.Household, error) {
+	const op = "Households.Create"
+
+	code, err := newCode()
+	if err != nil {
+		return models.Household{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := h.storage.CreateHousehold(userId, code); err != nil {
+		return models.Household{}, fmt.Errorf("%s: %w", op, householdError(err))
+	}
+	h.log.Info("household created", slog.Int64("owner", userId))
+	return h.Get(userId)
+}
+
+// Join добавляет юзера userId в семью с кодом приглашения code
+func (h *Households) Join(userId int64, code string) (models.Household, error) {
+	const op = "Households.Join"
+
+	household, err := h.storage.GetHouseholdByCode(strings.ToLower(strings.TrimSpace(code)))
+	if err != nil {
+		return models.Household{}, fmt.Errorf("%s: %w", op, householdError(err))
+	}
+	if err := h.storage.AddHouseholdMember(household.ID, userId); err != nil {
+		return models.Household{}, fmt.Errorf("%s: %w", op, householdError(err))
+	}
+	h.log.Info("household joined", slog.Int64("user", userId), slog.Int64("household", household.ID))
+	return h.Get(userId)
+}
+
+// Leave выводит юзера userId из семьи. Если уходит владелец, семья распускается,
+// тогда второй результат - true.
+func (h *Households) Leave(userId int64) (bool, error) {
+	const op = "Households.Leave"
+
+	household, err := h.storage.GetHousehold(userId)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, householdError(err))
+	}
+	if household.IsOwner(userId) {
+		if err := h.storage.DeleteHousehold(household.ID); err != nil {
+			return false, fmt.Errorf("%s: %w", op, householdError(err))
+		}
+		h.log.Info("household deleted", slog.Int64("owner", userId))
+		return true, nil
+	}
+	if err := h.storage.RemoveHouseholdMember(household.ID, userId); err != nil {
+		return false, fmt.Errorf("%s: %w", op, householdError(err))
+	}
+	h.log.Info("household left", slog.Int64("user", userId), slog.Int64("household", household.ID))
+	return false, nil
+}
+
+// Remove удаляет участника memberId из семьи. Удалять участников может только владелец ownerId,
+// сам владелец уходит через Leave. Код приглашения меняется, чтобы удаленный участник
+// не вернулся по старой ссылке; отдает семью с новым кодом.
+func (h *Households) Remove(ownerId int64, memberId int64) (models.Household, error) {
+	const op = "Households.Remove"
+
+	household, err := h.storage.GetHousehold(ownerId)
+	if err != nil {
+		return models.Household{}, fmt.Errorf("%s: %w", op, householdError(err))
+	}
+	if !household.IsOwner(ownerId) || memberId == ownerId {
+		return models.Household{}, fmt.Errorf("%s: %w", op, services.ErrAccessDenied)
+	}
+	if err := h.storage.RemoveHouseholdMember(household.ID, memberId); err != nil {
+		return models.Household{}, fmt.Errorf("%s: %w", op, householdError(err))
+	}
+	h.log.Info("household member removed", slog.Int64("owner", ownerId), slog.Int64("user", memberId))
+	code, err := newCode()
+	if err != nil {
+		return models.Household{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := h.storage.SetHouseholdCode(household.ID, code); err != nil {
+		return models.Household{}, fmt.Errorf("%s: %w", op, householdError(err))
+	}
+	return h.Get(ownerId)
+}
+
+// Account отдает id, от имени которого хранятся история, лимит и остатки юзера userId:
+// владельца семьи или самого пользователя, если он не состоит в семье.
+// При ошибке хранилища используется userId.
+func (h *Households) Account(userId int64) int64 {
+	const op = "Households.Account"
+
+	household, err := h.storage.GetHousehold(userId)
+	if err != nil {
+		if !errors.Is(err, storages.ErrHouseholdNotFound) {
+			h.log.Error("get household error", slog.String("op", op), slog.Any("error", err))
+		}
+		return userId
+	}
+	return household.OwnerID
+}
+
+// Restrictions отдает ограничения в питании юзера userId
+func (h *Households) Restrictions(userId int64) ([]string, error) {
+	const op = "Households.Restrictions"
+
+	items, err := h.storage.GetRestrictions(userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return items, nil
+}
+
+// SetRestrictions сохраняет ограничения в питании юзера userId:
+// названия блюд, теги или ингредиенты. Пустой список снимает ограничения.
+func (h *Households) SetRestrictions(userId int64, items []string) ([]string, error) {
+	const op = "Households.SetRestrictions"
+
+	items = normalizeItems(items)
+	if err := h.storage.SetRestrictions(userId, items); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return items, nil
+}
+
+// CombinedRestrictions отдает ограничения всех участников семьи юзера userId,
+// если он не состоит в семье - только его собственные
+func (h *Households) CombinedRestrictions(userId int64) ([]string, error) {
+	const op = "Households.CombinedRestrictions"
+
+	members := []int64{userId}
+	household, err := h.storage.GetHousehold(userId)
+	switch {
+	case err == nil:
+		members = members[:0]
+		for _, member := range household.Members {
+			members = append(members, member.ID)
+		}
+	case !errors.Is(err, storages.ErrHouseholdNotFound):
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	var items []string
+	for _, member := range members {
+		memberItems, err := h.storage.GetRestrictions(member)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		items = append(items, memberItems...)
+	}
+	return normalizeItems(items), nil
+}
+
+// normalizeItems приводит ограничения к нижнему регистру, убирает пустые и повторы
+func normalizeItems(items []string) []string {
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			res = append(res, item)
+		}
+	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+// newCode генерирует случайный код приглашения
+func newCode() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return strings.ToLower(codeEncoding.EncodeToString(buf)), nil
+}
+
+// householdError переводит ошибки хранилища в ошибки сервиса
+func householdError(err error) error {
+	switch {
+	case errors.Is(err, storages.ErrHouseholdNotFound):
+		return services.ErrHouseholdNotFound
+	case errors.Is(err, storages.ErrAlreadyInHousehold):
+		return services.ErrAlreadyInHousehold
+	case errors.Is(err, storages.ErrUserNotFound):
+		return services.ErrUserNotFound
+	}
+	return err
+}
@@ -0,0 +1,135 @@
+// Сервис остатков ужина: большую кастрюлю борща доедают несколько дней,
+// и в эти дни вместо нового ужина предлагаются остатки.
+package leftoversservice
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// Максимальное количество дней, на которые остаются остатки
+const MaxDays = 7
+
+type Leftovers struct {
+	log     *slog.Logger
+	storage LeftoverStorage
+}
+
+// Доступ к остаткам ужинов и истории запросов
+type LeftoverStorage interface {
+	GetHistory(userId int64) ([]models.HistoryEntry, error)
+	GetFoods() ([]models.Food, error)
+	GetLeftover(userId int64) (models.Leftover, error)
+	SaveLeftover(userId int64, leftover models.Leftover) error
+	DeleteLeftover(userId int64) error
+}
+
+// New - конструктор сервиса
+func New(log *slog.Logger, storage LeftoverStorage) *Leftovers {
+	return &Leftovers{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// Keep отмечает последний предложенный юзеру userId ужин как остатки на days дней после now.
+// Планы и заготовки (несколько ужинов сразу) пропускаются, блюда, удаленные из каталога, не остаются.
+// Остатки заканчиваются в полночь (в часовом поясе now) после последнего дня.
+func (l *Leftovers) Keep(userId int64, days int, now time.Time) (models.Leftover, error) {
+	const op = "Leftovers.Keep"
+
+	if days < 1 || days > MaxDays {
+		return models.Leftover{}, fmt.Errorf("%s: %w", op, services.ErrInvalidLeftoverDays)
+	}
+	history, err := l.storage.GetHistory(userId)
+	if err != nil {
+		return models.Leftover{}, fmt.Errorf("%s: %w", op, err)
+	}
+	var dinner []models.Food
+	for i := len(history) - 1; i >= 0; i-- {
+		if !history[i].Plan {
+			dinner = history[i].Foods
+			break
+		}
+	}
+	if len(dinner) == 0 {
+		return models.Leftover{}, fmt.Errorf("%s: %w", op, services.ErrNoDinnerToKeep)
+	}
+	foods, err := l.storage.GetFoods()
+	if err != nil {
+		return models.Leftover{}, fmt.Errorf("%s: %w", op, err)
+	}
+	dinner = slices.DeleteFunc(slices.Clone(dinner), func(food models.Food) bool {
+		return !slices.ContainsFunc(foods, func(f models.Food) bool { return f.ID == food.ID })
+	})
+	if len(dinner) == 0 {
+		return models.Leftover{}, fmt.Errorf("%s: %w", op, services.ErrNoDinnerToKeep)
+	}
+	year, month, day := now.Date()
+	leftover := models.Leftover{
+		Foods: dinner,
+		Until: time.Date(year, month, day+days+1, 0, 0, 0, 0, now.Location()),
+	}
+	if err := l.storage.SaveLeftover(userId, leftover); err != nil {
+		return models.Leftover{}, fmt.Errorf("%s: %w", op, err)
+	}
+	l.log.Info("leftovers kept", slog.Int64("user", userId), slog.Int("days", days))
+	return withCatalog(leftover, foods), nil
+}
+
+// Current отдает остатки ужина юзера userId на момент now.
+// Закончившиеся остатки удаляются, тогда второй результат - false.
+func (l *Leftovers) Current(userId int64, now time.Time) (models.Leftover, bool, error) {
+	const op = "Leftovers.Current"
+
+	leftover, err := l.storage.GetLeftover(userId)
+	if err != nil {
+		return models.Leftover{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(leftover.Foods) == 0 {
+		return models.Leftover{}, false, nil
+	}
+	if !leftover.Active(now) {
+		if err := l.storage.DeleteLeftover(userId); err != nil {
+			return models.Leftover{}, false, fmt.Errorf("%s: %w", op, err)
+		}
+		l.log.Debug("leftovers expired", slog.Int64("user", userId))
+		return models.Leftover{}, false, nil
+	}
+	foods, err := l.storage.GetFoods()
+	if err != nil {
+		return models.Leftover{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+	return withCatalog(leftover, foods), true, nil
+}
+
+// Clear удаляет остатки ужина юзера userId
+func (l *Leftovers) Clear(userId int64) error {
+	const op = "Leftovers.Clear"
+
+	if err := l.storage.DeleteLeftover(userId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// withCatalog заменяет блюда остатков полными записями из каталога foods:
+// с переводами названий, рецептами и пищевой ценностью
+func withCatalog(leftover models.Leftover, foods []models.Food) models.Leftover {
+	byID := make(map[int64]models.Food, len(foods))
+	for _, food := range foods {
+		byID[food.ID] = food
+	}
+	res := models.Leftover{Foods: make([]models.Food, 0, len(leftover.Foods)), Until: leftover.Until}
+	for _, food := range leftover.Foods {
+		if full, ok := byID[food.ID]; ok {
+			food = full
+		}
+		res.Foods = append(res.Foods, food)
+	}
+	return res
+}
@@ -0,0 +1,227 @@
+// Сервис настроек пользователя: язык, цель по калориям, бюджет, часовой пояс
+// и режим выбора ужина.
+package preferencesservice
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/i18n"
+	"dinner/internal/services"
+	dinnerservice "dinner/internal/services/dinner"
+	"dinner/internal/storages"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Наибольший бюджет на ужины за неделю в рублях
+const MaxBudget = 1000000
+
+type Preferences struct {
+	log     *slog.Logger
+	storage PreferenceStorage
+}
+
+// Доступ к настройкам пользователей
+type PreferenceStorage interface {
+	GetUserLang(userId int64) (string, error)
+	SetUserLang(userId int64, lang string) error
+	GetKcalTarget(userId int64) (models.KcalRange, error)
+	SetKcalTarget(userId int64, target models.KcalRange) error
+	GetBudget(userId int64) (int, error)
+	SetBudget(userId int64, budget int) error
+	GetTimezone(userId int64) (string, error)
+	SetTimezone(userId int64, timezone string) error
+	GetMode(userId int64) (string, error)
+	SetMode(userId int64, mode string) error
+}
+
+// New - конструктор сервиса
+func New(log *slog.Logger, storage PreferenceStorage) *Preferences {
+	return &Preferences{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// Language отдает язык сообщений для пользователя userId:
+// выбранный им командой /lang или язык клиента Telegram languageCode
+func (p *Preferences) Language(userId int64, languageCode string) i18n.Lang {
+	const op = "Preferences.Language"
+
+	override, err := p.storage.GetUserLang(userId)
+	if err != nil {
+		p.log.Error("get user lang error", slog.String("op", op), slog.Any("error", err))
+	}
+	return i18n.Resolve(override, languageCode)
+}
+
+// SetLanguage сохраняет язык сообщений для пользователя userId.
+// Значение "auto" возвращает язык клиента Telegram.
+func (p *Preferences) SetLanguage(userId int64, value string) error {
+	const op = "Preferences.SetLanguage"
+
+	lang := ""
+	if value != "auto" {
+		parsed, ok := i18n.Parse(value)
+		if !ok {
+			return fmt.Errorf("%s: %w: %s", op, services.ErrUnknownLanguage, value)
+		}
+		lang = string(parsed)
+	}
+	if err := p.storage.SetUserLang(userId, lang); err != nil {
+		if errors.Is(err, storages.ErrUserNotFound) {
+			return fmt.Errorf("%s: %w", op, services.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// KcalTarget отдает диапазон калорий ужина пользователя userId.
+// При ошибке хранилища ограничение не применяется.
+func (p *Preferences) KcalTarget(userId int64) models.KcalRange {
+	const op = "Preferences.KcalTarget"
+
+	target, err := p.storage.GetKcalTarget(userId)
+	if err != nil {
+		p.log.Error("get kcal target error", slog.String("op", op), slog.Any("error", err))
+		return models.KcalRange{}
+	}
+	return target
+}
+
+// SetKcalTarget сохраняет диапазон калорий ужина пользователя userId.
+// Пустой диапазон снимает ограничение.
+func (p *Preferences) SetKcalTarget(userId int64, target models.KcalRange) error {
+	const op = "Preferences.SetKcalTarget"
+
+	if err := p.storage.SetKcalTarget(userId, target); err != nil {
+		if errors.Is(err, storages.ErrUserNotFound) {
+			return fmt.Errorf("%s: %w", op, services.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Budget отдает бюджет пользователя userId на ужины за неделю в рублях, 0 - не задан.
+// При ошибке хранилища ограничение не применяется.
+func (p *Preferences) Budget(userId int64) int {
+	const op = "Preferences.Budget"
+
+	budget, err := p.storage.GetBudget(userId)
+	if err != nil {
+		p.log.Error("get budget error", slog.String("op", op), slog.Any("error", err))
+		return 0
+	}
+	return budget
+}
+
+// SetBudget сохраняет бюджет пользователя userId на ужины за неделю.
+// 0 снимает ограничение.
+func (p *Preferences) SetBudget(userId int64, budget int) error {
+	const op = "Preferences.SetBudget"
+
+	if budget < 0 || budget > MaxBudget {
+		return fmt.Errorf("%s: %w: %d", op, services.ErrInvalidBudget, budget)
+	}
+	if err := p.storage.SetBudget(userId, budget); err != nil {
+		if errors.Is(err, storages.ErrUserNotFound) {
+			return fmt.Errorf("%s: %w", op, services.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Now отдает текущее время в часовом поясе пользователя userId.
+// Если пояс не задан или не читается, используется часовой пояс сервера.
+func (p *Preferences) Now(userId int64) time.Time {
+	const op = "Preferences.Now"
+
+	now := time.Now()
+	timezone, err := p.storage.GetTimezone(userId)
+	if err != nil {
+		p.log.Error("get timezone error", slog.String("op", op), slog.Any("error", err))
+		return now
+	}
+	if timezone == "" {
+		return now
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		p.log.Error("load timezone error", slog.String("op", op), slog.String("timezone", timezone), slog.Any("error", err))
+		return now
+	}
+	return now.In(loc)
+}
+
+// Timezone отдает часовой пояс пользователя userId или пустую строку, если он не задан
+func (p *Preferences) Timezone(userId int64) (string, error) {
+	const op = "Preferences.Timezone"
+
+	timezone, err := p.storage.GetTimezone(userId)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	return timezone, nil
+}
+
+// SetTimezone сохраняет часовой пояс пользователя userId по имени из базы IANA
+// ("Europe/Moscow"). Значение "auto" возвращает часовой пояс сервера.
+func (p *Preferences) SetTimezone(userId int64, value string) error {
+	const op = "Preferences.SetTimezone"
+
+	timezone := ""
+	if value != "auto" {
+		loc, err := time.LoadLocation(value)
+		// Пустое имя LoadLocation принимает как UTC, а "Local" зависит от сервера
+		if err != nil || value == "" || value == "Local" {
+			return fmt.Errorf("%s: %w: %s", op, services.ErrUnknownTimezone, value)
+		}
+		timezone = loc.String()
+	}
+	if err := p.storage.SetTimezone(userId, timezone); err != nil {
+		if errors.Is(err, storages.ErrUserNotFound) {
+			return fmt.Errorf("%s: %w", op, services.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Mode отдает стратегию выбора ужина пользователя userId (см. dinnerservice.StrategyByName).
+// При ошибке хранилища используется стратегия по умолчанию.
+func (p *Preferences) Mode(userId int64) string {
+	const op = "Preferences.Mode"
+
+	mode, err := p.storage.GetMode(userId)
+	if err != nil {
+		p.log.Error("get mode error", slog.String("op", op), slog.Any("error", err))
+		return ""
+	}
+	return mode
+}
+
+// SetMode сохраняет стратегию выбора ужина пользователя userId.
+// Значение "auto" возвращает стратегию по умолчанию.
+func (p *Preferences) SetMode(userId int64, value string) error {
+	const op = "Preferences.SetMode"
+
+	mode := ""
+	if value != "auto" {
+		strategy, ok := dinnerservice.StrategyByName(value)
+		if !ok || value == "" {
+			return fmt.Errorf("%s: %w: %s", op, services.ErrUnknownMode, value)
+		}
+		mode = strategy.Name()
+	}
+	if err := p.storage.SetMode(userId, mode); err != nil {
+		if errors.Is(err, storages.ErrUserNotFound) {
+			return fmt.Errorf("%s: %w", op, services.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
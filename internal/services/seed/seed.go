@@ -0,0 +1,186 @@
+// Сервис загрузки начальных данных каталога блюд.
+// Данные берутся из YAML или JSON файла и применяются к БД без удаления
+// блюд, добавленных пользователями.
+package seedservice
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Seeder struct {
+	log     *slog.Logger
+	storage SeedStorage
+}
+
+// Применение начальных данных к хранилищу
+type SeedStorage interface {
+	ApplySeed(seed models.Seed) (models.SeedReport, error)
+}
+
+// Структура файла с начальными данными
+type seedFile struct {
+	Categories []struct {
+		ID   int    `yaml:"id" json:"id"`
+		Name string `yaml:"name" json:"name"`
+	} `yaml:"categories" json:"categories"`
+	Foods []struct {
+		Name     string `yaml:"name" json:"name"`
+		Category string `yaml:"category" json:"category"`
+		// Переводы названия по коду языка
+		Names map[string]string `yaml:"names" json:"names"`
+		// Ингредиенты, нужны для подбора ужина по продуктам (/cook)
+		Ingredients []string `yaml:"ingredients" json:"ingredients"`
+		// Пищевая ценность порции
+		Nutrition *nutrition `yaml:"nutrition" json:"nutrition"`
+		// Времена года, в которые блюдо уместно ("winter", "summer")
+		Seasons []string `yaml:"seasons" json:"seasons"`
+		// Предпочтительные дни недели ("thu")
+		Weekdays []string `yaml:"weekdays" json:"weekdays"`
+	} `yaml:"foods" json:"foods"`
+	// Сочетания мяса и гарниров: вес "forbid", "allow", "prefer" или число
+	Pairings []struct {
+		Meat   string `yaml:"meat" json:"meat"`
+		Side   string `yaml:"side" json:"side"`
+		Weight string `yaml:"weight" json:"weight"`
+	} `yaml:"pairings" json:"pairings"`
+}
+
+// Пищевая ценность порции в файле с начальными данными
+type nutrition struct {
+	Kcal    float64 `yaml:"kcal" json:"kcal"`
+	Protein float64 `yaml:"protein" json:"protein"`
+	Fat     float64 `yaml:"fat" json:"fat"`
+	Carbs   float64 `yaml:"carbs" json:"carbs"`
+}
+
+// New - конструктор сервиса
+func New(log *slog.Logger, storage SeedStorage) *Seeder {
+	return &Seeder{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// Load читает начальные данные из файла path.
+// Формат определяется по расширению: .yaml, .yml или .json.
+func Load(path string) (models.Seed, error) {
+	const op = "seedservice.Load"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.Seed{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var file seedFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	default:
+		return models.Seed{}, fmt.Errorf("%s: %w: %s", op, services.ErrUnknownFormat, path)
+	}
+	if err != nil {
+		return models.Seed{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return parse(file)
+}
+
+// parse проверяет данные файла и переводит их в модели.
+// Блюда ссылаются на категории по названию.
+func parse(file seedFile) (models.Seed, error) {
+	const op = "seedservice.parse"
+
+	seed := models.Seed{}
+	categories := make(map[string]models.FootCategory, len(file.Categories))
+	for _, c := range file.Categories {
+		name := strings.TrimSpace(c.Name)
+		if c.ID <= 0 || name == "" {
+			return seed, fmt.Errorf("%s: %w: category %d %q", op, services.ErrInvalidSeed, c.ID, c.Name)
+		}
+		if _, ok := categories[name]; ok {
+			return seed, fmt.Errorf("%s: %w: duplicate category %q", op, services.ErrInvalidSeed, name)
+		}
+		categories[name] = models.FootCategory(c.ID)
+		seed.Categories = append(seed.Categories, models.Category{ID: models.FootCategory(c.ID), Name: name})
+	}
+
+	names := make(map[string]models.FootCategory, len(file.Foods))
+	for _, f := range file.Foods {
+		name := strings.TrimSpace(f.Name)
+		if name == "" {
+			return seed, fmt.Errorf("%s: %w: empty food name", op, services.ErrInvalidSeed)
+		}
+		if _, ok := names[name]; ok {
+			return seed, fmt.Errorf("%s: %w: duplicate food %q", op, services.ErrInvalidSeed, name)
+		}
+		category, ok := categories[strings.TrimSpace(f.Category)]
+		if !ok {
+			return seed, fmt.Errorf("%s: %w: unknown category %q for food %q", op, services.ErrInvalidSeed, f.Category, name)
+		}
+		names[name] = category
+		food := models.Food{
+			Name:        name,
+			Category:    category,
+			Names:       f.Names,
+			Ingredients: models.NormalizeList(f.Ingredients),
+		}
+		if n := f.Nutrition; n != nil {
+			if n.Kcal < 0 || n.Protein < 0 || n.Fat < 0 || n.Carbs < 0 {
+				return seed, fmt.Errorf("%s: %w: negative nutrition for food %q", op, services.ErrInvalidSeed, name)
+			}
+			food.Nutrition = &models.Nutrition{Kcal: n.Kcal, Protein: n.Protein, Fat: n.Fat, Carbs: n.Carbs}
+		}
+		var err error
+		if food.Seasons, err = models.ParseSeasons(f.Seasons); err != nil {
+			return seed, fmt.Errorf("%s: %w: food %q: %w", op, services.ErrInvalidSeed, name, err)
+		}
+		if food.Weekdays, err = models.ParseWeekdays(f.Weekdays); err != nil {
+			return seed, fmt.Errorf("%s: %w: food %q: %w", op, services.ErrInvalidSeed, name, err)
+		}
+		seed.Foods = append(seed.Foods, food)
+	}
+
+	// Сочетания ссылаются на блюда из этого же файла
+	for _, p := range file.Pairings {
+		meat, side := strings.TrimSpace(p.Meat), strings.TrimSpace(p.Side)
+		if names[meat] != models.Meat || names[side] != models.SideDish {
+			return seed, fmt.Errorf("%s: %w: pairing %q + %q must be meat and side dish from foods", op, services.ErrInvalidSeed, meat, side)
+		}
+		weight, ok := models.ParsePairWeight(p.Weight)
+		if !ok {
+			return seed, fmt.Errorf("%s: %w: pairing %q + %q: unknown weight %q", op, services.ErrInvalidSeed, meat, side, p.Weight)
+		}
+		seed.Pairings = append(seed.Pairings, models.SeedPairing{Meat: meat, Side: side, Weight: weight})
+	}
+	return seed, nil
+}
+
+// Apply применяет начальные данные к хранилищу и возвращает отчет об изменениях.
+func (s *Seeder) Apply(seed models.Seed) (models.SeedReport, error) {
+	const op = "Seeder.Apply"
+
+	log := s.log.With(
+		slog.String("op", op),
+	)
+	report, err := s.storage.ApplySeed(seed)
+	if err != nil {
+		return report, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("seed applied",
+		slog.Int("added", len(report.Added)),
+		slog.Int("updated", len(report.Updated)),
+		slog.Int("unchanged", report.Unchanged),
+	)
+	return report, nil
+}
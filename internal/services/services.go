@@ -8,4 +8,48 @@ var (
 	ErrAttemptLimitExceeded = errors.New("user attempt limit exceeded")
 	// Не удалось сформировать ужин
 	ErrEmptyFood = errors.New("food is empty")
+	// Неизвестный формат файла
+	ErrUnknownFormat = errors.New("unknown file format")
+	// Некорректные начальные данные
+	ErrInvalidSeed = errors.New("invalid seed data")
+	// Некорректный файл каталога блюд
+	ErrInvalidCatalog = errors.New("invalid catalog data")
+	// Недостаточно прав для выполнения команды
+	ErrAccessDenied = errors.New("access denied")
+	// Пользователь не найден
+	ErrUserNotFound = errors.New("user not found")
+	// Неподдерживаемый язык
+	ErrUnknownLanguage = errors.New("unknown language")
+	// Некорректное количество дней в плане ужинов
+	ErrInvalidPlanDays = errors.New("invalid number of plan days")
+	// Некорректное количество ужинов или порций в заготовке
+	ErrInvalidMealPrep = errors.New("invalid meal prep size")
+	// Некорректная цена блюда или ингредиента
+	ErrInvalidPrice = errors.New("invalid price")
+	// Некорректный бюджет на ужины
+	ErrInvalidBudget = errors.New("invalid budget")
+	// Блюдо не найдено
+	ErrFoodNotFound = errors.New("food not found")
+	// Блюдо с таким названием уже есть
+	ErrFoodExists = errors.New("food already exists")
+	// Некорректный диапазон калорий
+	ErrInvalidKcalTarget = errors.New("invalid kcal target")
+	// Ни один ужин не подходит под условия подбора
+	ErrNoMatchingDinner = errors.New("no dinner matches the conditions")
+	// Некорректное сочетание мяса и гарнира
+	ErrInvalidPairing = errors.New("invalid pairing")
+	// Неизвестный часовой пояс
+	ErrUnknownTimezone = errors.New("unknown timezone")
+	// Некорректное количество дней для остатков ужина
+	ErrInvalidLeftoverDays = errors.New("invalid number of leftover days")
+	// Нет ужина, который можно отметить как остатки
+	ErrNoDinnerToKeep = errors.New("no dinner to keep as leftovers")
+	// Семья не найдена
+	ErrHouseholdNotFound = errors.New("household not found")
+	// Пользователь уже состоит в семье
+	ErrAlreadyInHousehold = errors.New("user is already in a household")
+	// Неизвестная стратегия выбора ужина
+	ErrUnknownMode = errors.New("unknown dinner mode")
+	// Некорректная оценка блюда
+	ErrInvalidRating = errors.New("invalid rating")
 )
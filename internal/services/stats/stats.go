@@ -0,0 +1,205 @@
+// Сервис личной статистики пользователя по истории запросов.
+package statsservice
+
+import (
+	"dinner/internal/domain/models"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Сколько блюд показывать в списках самых частых и самых редких
+const topSize = 3
+
+type Stats struct {
+	log             *slog.Logger
+	historyProvider HistoryProvider
+}
+
+// Доступ к истории запросов пользователей
+type HistoryProvider interface {
+	GetHistory(userId int64) ([]models.HistoryEntry, error)
+	GetCategories() ([]models.Category, error)
+}
+
+// New - конструктор сервиса
+func New(log *slog.Logger, historyProvider HistoryProvider) *Stats {
+	return &Stats{
+		log:             log,
+		historyProvider: historyProvider,
+	}
+}
+
+// GetStats считает статистику юзера userId на момент now.
+// Месяц и серии дней считаются в часовом поясе now.
+func (s *Stats) GetStats(userId int64, now time.Time) (models.UserStats, error) {
+	const op = "Stats.GetStats"
+
+	history, err := s.historyProvider.GetHistory(userId)
+	if err != nil {
+		return models.UserStats{}, fmt.Errorf("%s: %w", op, err)
+	}
+	categories, err := s.historyProvider.GetCategories()
+	if err != nil {
+		return models.UserStats{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return Calculate(history, categories, now), nil
+}
+
+// Calculate считает статистику по истории запросов history на момент now
+func Calculate(history []models.HistoryEntry, categories []models.Category, now time.Time) models.UserStats {
+	stats := models.UserStats{
+		TotalRequests: len(history),
+	}
+
+	foodCounts := map[int64]*models.FoodCount{}
+	categoryCounts := map[models.FootCategory]int{}
+	days := map[time.Time]struct{}{}
+	year, month, _ := now.Date()
+	for _, entry := range history {
+		t := entry.Time.In(now.Location())
+		if y, m, _ := t.Date(); y == year && m == month {
+			stats.MonthRequests++
+		}
+		days[truncateDay(t)] = struct{}{}
+		for _, food := range entry.Foods {
+			if count, ok := foodCounts[food.ID]; ok {
+				count.Count++
+			} else {
+				foodCounts[food.ID] = &models.FoodCount{Food: food, Count: 1}
+			}
+			categoryCounts[food.Category]++
+		}
+	}
+
+	// Самые частые и самые редкие блюда
+	counts := make([]models.FoodCount, 0, len(foodCounts))
+	for _, count := range foodCounts {
+		counts = append(counts, *count)
+	}
+	slices.SortFunc(counts, func(a, b models.FoodCount) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		if a.Food.Name < b.Food.Name {
+			return -1
+		}
+		if a.Food.Name > b.Food.Name {
+			return 1
+		}
+		return 0
+	})
+	stats.TopFoods = counts[:min(topSize, len(counts))]
+	bottom := slices.Clone(counts[max(0, len(counts)-topSize):])
+	slices.Reverse(bottom)
+	stats.BottomFoods = bottom
+
+	// Распределение по категориям в порядке категорий
+	for _, category := range categories {
+		if count := categoryCounts[category.ID]; count > 0 {
+			stats.Categories = append(stats.Categories, models.CategoryCount{Category: category, Count: count})
+		}
+	}
+
+	stats.CurrentStreak, stats.LongestStreak = streaks(days, truncateDay(now))
+	return stats
+}
+
+// streaks считает текущую и самую длинную серию дней подряд с запросами.
+// Текущая серия не прерывается, если сегодня запросов еще не было.
+func streaks(days map[time.Time]struct{}, today time.Time) (current int, longest int) {
+	sorted := make([]time.Time, 0, len(days))
+	for day := range days {
+		sorted = append(sorted, day)
+	}
+	slices.SortFunc(sorted, func(a, b time.Time) int { return a.Compare(b) })
+
+	run := 0
+	for i, day := range sorted {
+		if i > 0 && sameDay(sorted[i-1].AddDate(0, 0, 1), day) {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+
+	if len(sorted) > 0 {
+		last := sorted[len(sorted)-1]
+		if sameDay(last, today) || sameDay(last.AddDate(0, 0, 1), today) {
+			current = run
+		}
+	}
+	return current, longest
+}
+
+// truncateDay отдает начало дня t в часовом поясе t
+func truncateDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// sameDay проверяет, что a и b - один календарный день
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// GetHistory отдает историю запросов юзера userId
+func (s *Stats) GetHistory(userId int64) ([]models.HistoryEntry, error) {
+	const op = "Stats.GetHistory"
+
+	history, err := s.historyProvider.GetHistory(userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return history, nil
+}
+
+// ExportHistory выгружает историю юзера userId в w в формате CSV.
+// Каждое предложенное блюдо записывается отдельной строкой.
+func (s *Stats) ExportHistory(userId int64, w io.Writer) error {
+	const op = "Stats.ExportHistory"
+
+	history, err := s.historyProvider.GetHistory(userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	categories, err := s.historyProvider.GetCategories()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	names := make(map[models.FootCategory]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"request", "time", "food", "category"}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for i, entry := range history {
+		request := strconv.Itoa(i + 1)
+		dt := entry.Time.Format(time.RFC3339)
+		if len(entry.Foods) == 0 {
+			if err := writer.Write([]string{request, dt, "", ""}); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		for _, food := range entry.Foods {
+			if err := writer.Write([]string{request, dt, food.Name, names[food.Category]}); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package storagesqlite
+
+import (
+	"database/sql"
+	"dinner/internal/domain/models"
+	"dinner/internal/storages"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// saveFoodDetails заменяет теги, ингредиенты, переводы названия, пищевую ценность,
+// сезоны и дни недели блюда id
+func saveFoodDetails(tx *sql.Tx, id int64, food models.Food) error {
+	if _, err := tx.Exec("DELETE FROM food_tags WHERE foodId=?", id); err != nil {
+		return err
+	}
+	for _, tag := range food.Tags {
+		if _, err := tx.Exec("INSERT INTO food_tags(foodId, tag) VALUES(?, ?)", id, tag); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM food_ingredients WHERE foodId=?", id); err != nil {
+		return err
+	}
+	for _, ingredient := range food.Ingredients {
+		if _, err := tx.Exec("INSERT INTO food_ingredients(foodId, ingredient) VALUES(?, ?)", id, ingredient); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM food_names WHERE foodId=?", id); err != nil {
+		return err
+	}
+	for lang, name := range food.Names {
+		if _, err := tx.Exec("INSERT INTO food_names(foodId, lang, name) VALUES(?, ?, ?)", id, lang, name); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM food_nutrition WHERE foodId=?", id); err != nil {
+		return err
+	}
+	if food.Nutrition != nil {
+		if _, err := replaceNutrition(tx, id, *food.Nutrition); err != nil {
+			return err
+		}
+	}
+	if _, err := replaceFoodValues(tx, "food_seasons", "season", id, seasonValues(food.Seasons)); err != nil {
+		return err
+	}
+	if _, err := replaceFoodValues(tx, "food_weekdays", "weekday", id, weekdayValues(food.Weekdays)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// seasonValues отдает отсортированные названия сезонов для хранения в БД
+func seasonValues(seasons []models.Season) []string {
+	return slices.Compact(slices.Sorted(slices.Values(models.SeasonNames(seasons))))
+}
+
+// weekdayValues отдает отсортированные названия дней недели для хранения в БД
+func weekdayValues(days []time.Weekday) []string {
+	return slices.Compact(slices.Sorted(slices.Values(models.WeekdayNames(days))))
+}
+
+// replaceNutrition сохраняет пищевую ценность блюда id, если она отличается от текущей
+func replaceNutrition(tx *sql.Tx, id int64, nutrition models.Nutrition) (bool, error) {
+	var current models.Nutrition
+	err := tx.QueryRow("SELECT kcal, protein, fat, carbs FROM food_nutrition WHERE foodId=?", id).
+		Scan(&current.Kcal, &current.Protein, &current.Fat, &current.Carbs)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	if err == nil && current == nutrition {
+		return false, nil
+	}
+	_, err = tx.Exec(
+		`INSERT INTO food_nutrition(foodId, kcal, protein, fat, carbs) VALUES(?, ?, ?, ?, ?)
+		ON CONFLICT(foodId) DO UPDATE SET kcal=excluded.kcal, protein=excluded.protein, fat=excluded.fat, carbs=excluded.carbs`,
+		id, nutrition.Kcal, nutrition.Protein, nutrition.Fat, nutrition.Carbs,
+	)
+	return err == nil, err
+}
+
+// replaceFoodValues заменяет значения column блюда id в таблице table (например,
+// ингредиенты в food_ingredients), если они отличаются от values.
+// values должны быть отсортированы, table и column - только константы.
+func replaceFoodValues(tx *sql.Tx, table, column string, id int64, values []string) (bool, error) {
+	rows, err := tx.Query(fmt.Sprintf("SELECT %s FROM %s WHERE foodId=? ORDER BY %s", column, table, column), id)
+	if err != nil {
+		return false, err
+	}
+	current := make([]string, 0, len(values))
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			rows.Close()
+			return false, err
+		}
+		current = append(current, value)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	if slices.Equal(current, values) {
+		return false, nil
+	}
+	if _, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE foodId=?", table), id); err != nil {
+		return false, err
+	}
+	for _, value := range values {
+		if _, err := tx.Exec(fmt.Sprintf("INSERT INTO %s(foodId, %s) VALUES(?, ?)", table, column), id, value); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// isUniqueViolation проверяет, что ошибка - нарушение уникальности (в том числе первичного ключа)
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) &&
+		(sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey)
+}
+
+// CreateFood добавляет блюдо и отдает его id
+func (s *Storage) CreateFood(food models.Food) (int64, error) {
+	const op = "storagesqlite.CreateFood"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, storageError(op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("INSERT INTO foods(name, category, recipe) VALUES(?, ?, ?)", food.Name, food.Category, food.Recipe)
+	if isUniqueViolation(err) {
+		return 0, storages.ErrFoodExists
+	}
+	if err != nil {
+		return 0, storageError(op, err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, storageError(op, err)
+	}
+	if err := saveFoodDetails(tx, id, food); err != nil {
+		return 0, storageError(op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, storageError(op, err)
+	}
+	return id, nil
+}
+
+// UpdateFood обновляет блюдо food.ID
+func (s *Storage) UpdateFood(food models.Food) error {
+	const op = "storagesqlite.UpdateFood"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storageError(op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE foods SET name=?, category=?, recipe=? WHERE id=?", food.Name, food.Category, food.Recipe, food.ID)
+	if isUniqueViolation(err) {
+		return storages.ErrFoodExists
+	}
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrFoodNotFound
+	}
+	if err := saveFoodDetails(tx, food.ID, food); err != nil {
+		return storageError(op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
+
+// SetFoodPhoto сохраняет фото photo блюда id, пустой photo - удалить фото.
+// Фото не меняется при импорте каталога и изменении блюда.
+func (s *Storage) SetFoodPhoto(id int64, photo string) error {
+	const op = "storagesqlite.SetFoodPhoto"
+
+	res, err := s.db.Exec("UPDATE foods SET photo=? WHERE id=?", photo, id)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrFoodNotFound
+	}
+	return nil
+}
+
+// DeleteFood удаляет блюдо id. Его теги, ингредиенты, переводы, пищевая ценность, календарь,
+// сочетания с другими блюдами, оценки, места в очередях и остатках пользователей
+// удаляются каскадно по внешним ключам. История запросов ссылается на блюдо без внешнего ключа
+// и хранит его название, поэтому блюдо остается в истории и статистике.
+func (s *Storage) DeleteFood(id int64) error {
+	const op = "storagesqlite.DeleteFood"
+
+	res, err := s.db.Exec("DELETE FROM foods WHERE id=?", id)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrFoodNotFound
+	}
+	return nil
+}
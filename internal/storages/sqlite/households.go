@@ -0,0 +1,250 @@
+package storagesqlite
+
+import (
+	"database/sql"
+	"dinner/internal/domain/models"
+	"dinner/internal/storages"
+	"errors"
+)
+
+// GetHousehold отдает семью, в которой состоит юзер userId, вместе с участниками
+func (s *Storage) GetHousehold(userId int64) (models.Household, error) {
+	const op = "storagesqlite.GetHousehold"
+
+	household, err := s.getHousehold(`SELECT h.id, h.ownerId, h.code
+		FROM households h
+		JOIN household_members m ON m.householdId = h.id
+		WHERE m.userId = ?`, userId)
+	if err != nil {
+		if errors.Is(err, storages.ErrHouseholdNotFound) {
+			return models.Household{}, err
+		}
+		return models.Household{}, storageError(op, err)
+	}
+	return household, nil
+}
+
+// GetHouseholdByCode отдает семью по коду приглашения
+func (s *Storage) GetHouseholdByCode(code string) (models.Household, error) {
+	const op = "storagesqlite.GetHouseholdByCode"
+
+	household, err := s.getHousehold("SELECT id, ownerId, code FROM households WHERE code = ?", code)
+	if err != nil {
+		if errors.Is(err, storages.ErrHouseholdNotFound) {
+			return models.Household{}, err
+		}
+		return models.Household{}, storageError(op, err)
+	}
+	return household, nil
+}
+
+// getHousehold загружает семью по запросу query и ее участников
+func (s *Storage) getHousehold(query string, args ...any) (models.Household, error) {
+	var household models.Household
+	err := s.db.QueryRow(query, args...).Scan(&household.ID, &household.OwnerID, &household.Code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Household{}, storages.ErrHouseholdNotFound
+	}
+	if err != nil {
+		return models.Household{}, err
+	}
+
+	rows, err := s.db.Query(`SELECT m.userId, COALESCE(u.chatId, 0), COALESCE(u.userName, '')
+		FROM household_members m
+		LEFT JOIN users u ON u.id = m.userId
+		WHERE m.householdId = ?
+		ORDER BY m.rowid`, household.ID)
+	if err != nil {
+		return models.Household{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(&user.ID, &user.ChatID, &user.UserName); err != nil {
+			return models.Household{}, err
+		}
+		household.Members = append(household.Members, user)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Household{}, err
+	}
+	return household, nil
+}
+
+// CreateHousehold создает семью владельца ownerId с кодом приглашения code.
+// Владелец становится первым участником.
+func (s *Storage) CreateHousehold(ownerId int64, code string) (int64, error) {
+	const op = "storagesqlite.CreateHousehold"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, storageError(op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("INSERT INTO households(ownerId, code) VALUES(?, ?)", ownerId, code)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, storages.ErrAlreadyInHousehold
+		}
+		return 0, storageError(op, err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, storageError(op, err)
+	}
+	if err := addHouseholdMember(tx, id, ownerId); err != nil {
+		if errors.Is(err, storages.ErrAlreadyInHousehold) {
+			return 0, err
+		}
+		return 0, storageError(op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, storageError(op, err)
+	}
+	return id, nil
+}
+
+// AddHouseholdMember добавляет юзера userId в семью householdId
+func (s *Storage) AddHouseholdMember(householdId int64, userId int64) error {
+	const op = "storagesqlite.AddHouseholdMember"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storageError(op, err)
+	}
+	defer tx.Rollback()
+
+	if err := addHouseholdMember(tx, householdId, userId); err != nil {
+		if errors.Is(err, storages.ErrAlreadyInHousehold) {
+			return err
+		}
+		return storageError(op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
+
+// addHouseholdMember добавляет участника, пользователь может состоять только в одной семье
+func addHouseholdMember(tx *sql.Tx, householdId int64, userId int64) error {
+	_, err := tx.Exec("INSERT INTO household_members(userId, householdId) VALUES(?, ?)", userId, householdId)
+	if isUniqueViolation(err) {
+		return storages.ErrAlreadyInHousehold
+	}
+	return err
+}
+
+// RemoveHouseholdMember удаляет юзера userId из семьи householdId
+func (s *Storage) RemoveHouseholdMember(householdId int64, userId int64) error {
+	const op = "storagesqlite.RemoveHouseholdMember"
+
+	res, err := s.db.Exec("DELETE FROM household_members WHERE householdId=? AND userId=?", householdId, userId)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrUserNotFound
+	}
+	return nil
+}
+
+// SetHouseholdCode меняет код приглашения семьи householdId
+func (s *Storage) SetHouseholdCode(householdId int64, code string) error {
+	const op = "storagesqlite.SetHouseholdCode"
+
+	res, err := s.db.Exec("UPDATE households SET code=? WHERE id=?", code, householdId)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrHouseholdNotFound
+	}
+	return nil
+}
+
+// DeleteHousehold удаляет семью id вместе со списком участников.
+// История запросов остается у владельца.
+func (s *Storage) DeleteHousehold(id int64) error {
+	const op = "storagesqlite.DeleteHousehold"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storageError(op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM household_members WHERE householdId=?", id); err != nil {
+		return storageError(op, err)
+	}
+	res, err := tx.Exec("DELETE FROM households WHERE id=?", id)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrHouseholdNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
+
+// GetRestrictions отдает ограничения в питании юзера userId:
+// названия блюд, теги и ингредиенты, которые ему не предлагаются
+func (s *Storage) GetRestrictions(userId int64) ([]string, error) {
+	const op = "storagesqlite.GetRestrictions"
+
+	rows, err := s.db.Query("SELECT item FROM user_restrictions WHERE userId=? ORDER BY item", userId)
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var item string
+		if err := rows.Scan(&item); err != nil {
+			return nil, storageError(op, err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, storageError(op, err)
+	}
+	return items, nil
+}
+
+// SetRestrictions заменяет ограничения в питании юзера userId
+func (s *Storage) SetRestrictions(userId int64, items []string) error {
+	const op = "storagesqlite.SetRestrictions"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storageError(op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM user_restrictions WHERE userId=?", userId); err != nil {
+		return storageError(op, err)
+	}
+	for _, item := range items {
+		if _, err := tx.Exec("INSERT OR IGNORE INTO user_restrictions(userId, item) VALUES(?, ?)", userId, item); err != nil {
+			return storageError(op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
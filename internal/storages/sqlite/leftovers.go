@@ -0,0 +1,75 @@
+package storagesqlite
+
+import "dinner/internal/domain/models"
+
+// GetLeftover отдает остатки ужина юзера userId.
+// Если остатков нет, у результата пустой список блюд.
+func (s *Storage) GetLeftover(userId int64) (models.Leftover, error) {
+	const op = "storagesqlite.GetLeftover"
+
+	rows, err := s.db.Query(`SELECT l.until, f.id, f.name, f.category
+		FROM leftovers l
+		JOIN foods f ON f.id = l.foodId
+		WHERE l.userId = ?
+		ORDER BY l.rowid`, userId)
+	if err != nil {
+		return models.Leftover{}, storageError(op, err)
+	}
+	defer rows.Close()
+
+	var leftover models.Leftover
+	for rows.Next() {
+		var until string
+		var food models.Food
+		if err := rows.Scan(&until, &food.ID, &food.Name, &food.Category); err != nil {
+			return models.Leftover{}, storageError(op, err)
+		}
+		if leftover.Until, err = parseTime(until); err != nil {
+			return models.Leftover{}, storageError(op, err)
+		}
+		leftover.Foods = append(leftover.Foods, food)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Leftover{}, storageError(op, err)
+	}
+	return leftover, nil
+}
+
+// SaveLeftover заменяет остатки ужина юзера userId
+func (s *Storage) SaveLeftover(userId int64, leftover models.Leftover) error {
+	const op = "storagesqlite.SaveLeftover"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storageError(op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM leftovers WHERE userId=?", userId); err != nil {
+		return storageError(op, err)
+	}
+	for _, food := range leftover.Foods {
+		_, err := tx.Exec(
+			"INSERT OR IGNORE INTO leftovers(userId, foodId, until) VALUES(?, ?, ?)",
+			userId, food.ID, leftover.Until,
+		)
+		if err != nil {
+			return storageError(op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
+
+// DeleteLeftover удаляет остатки ужина юзера userId
+func (s *Storage) DeleteLeftover(userId int64) error {
+	const op = "storagesqlite.DeleteLeftover"
+
+	if _, err := s.db.Exec("DELETE FROM leftovers WHERE userId=?", userId); err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package storagesqlite
+
+import (
+	"database/sql"
+	"dinner/internal/domain/models"
+	"errors"
+)
+
+// GetPairings отдает сочетания мяса и гарниров, вес которых отличается от обычного
+func (s *Storage) GetPairings() ([]models.Pairing, error) {
+	const op = "storagesqlite.GetPairings"
+
+	rows, err := s.db.Query("SELECT meatId, sideId, weight FROM food_pairings ORDER BY meatId, sideId")
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	defer rows.Close()
+	pairings := []models.Pairing{}
+	for rows.Next() {
+		var p models.Pairing
+		if err := rows.Scan(&p.MeatID, &p.SideID, &p.Weight); err != nil {
+			return nil, storageError(op, err)
+		}
+		pairings = append(pairings, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, storageError(op, err)
+	}
+	return pairings, nil
+}
+
+// SetPairing сохраняет вес сочетания мяса и гарнира.
+// Обычный вес (models.PairAllowed) удаляет сочетание из таблицы.
+func (s *Storage) SetPairing(pairing models.Pairing) error {
+	const op = "storagesqlite.SetPairing"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storageError(op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := replacePairing(tx, pairing); err != nil {
+		return storageError(op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
+
+// replacePairing сохраняет вес сочетания, если он отличается от текущего
+func replacePairing(tx *sql.Tx, pairing models.Pairing) (bool, error) {
+	current := models.PairAllowed
+	err := tx.QueryRow("SELECT weight FROM food_pairings WHERE meatId=? AND sideId=?", pairing.MeatID, pairing.SideID).Scan(&current)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	if current == pairing.Weight {
+		return false, nil
+	}
+	if pairing.Weight == models.PairAllowed {
+		_, err = tx.Exec("DELETE FROM food_pairings WHERE meatId=? AND sideId=?", pairing.MeatID, pairing.SideID)
+		return err == nil, err
+	}
+	_, err = tx.Exec(
+		`INSERT INTO food_pairings(meatId, sideId, weight) VALUES(?, ?, ?)
+		ON CONFLICT(meatId, sideId) DO UPDATE SET weight=excluded.weight`,
+		pairing.MeatID, pairing.SideID, pairing.Weight,
+	)
+	return err == nil, err
+}
@@ -0,0 +1,68 @@
+package storagesqlite
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/storages"
+)
+
+// GetIngredientPrices отдает примерные цены ингредиентов на порцию
+func (s *Storage) GetIngredientPrices() (models.Prices, error) {
+	const op = "storagesqlite.GetIngredientPrices"
+
+	rows, err := s.db.Query("SELECT ingredient, price FROM ingredient_prices")
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	defer rows.Close()
+	prices := models.Prices{}
+	for rows.Next() {
+		var ingredient string
+		var price int
+		if err := rows.Scan(&ingredient, &price); err != nil {
+			return nil, storageError(op, err)
+		}
+		prices[ingredient] = price
+	}
+	if err := rows.Err(); err != nil {
+		return nil, storageError(op, err)
+	}
+	return prices, nil
+}
+
+// SetIngredientPrice сохраняет цену ингредиента на порцию (ключ - models.PriceKey).
+// Нулевая цена удаляет цену ингредиента.
+func (s *Storage) SetIngredientPrice(ingredient string, price int) error {
+	const op = "storagesqlite.SetIngredientPrice"
+
+	var err error
+	if price == 0 {
+		_, err = s.db.Exec("DELETE FROM ingredient_prices WHERE ingredient=?", ingredient)
+	} else {
+		_, err = s.db.Exec(
+			`INSERT INTO ingredient_prices(ingredient, price) VALUES(?, ?)
+			ON CONFLICT(ingredient) DO UPDATE SET price=excluded.price`,
+			ingredient, price,
+		)
+	}
+	if err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
+
+// SetFoodPrice сохраняет цену порции блюда id, 0 - считать по ценам ингредиентов.
+// Цена не меняется при импорте каталога и изменении блюда.
+func (s *Storage) SetFoodPrice(id int64, price int) error {
+	const op = "storagesqlite.SetFoodPrice"
+
+	res, err := s.db.Exec("UPDATE foods SET price=? WHERE id=?", price, id)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrFoodNotFound
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package storagesqlite
+
+import (
+	"dinner/internal/domain/models"
+)
+
+// GetQueue отдает очередь блюд пользователя userId для режима "все блюда по разу"
+func (s *Storage) GetQueue(userId int64) (models.FoodQueue, error) {
+	const op = "storagesqlite.GetQueue"
+
+	rows, err := s.db.Query("SELECT foodId, served FROM food_queue WHERE userId=? ORDER BY position", userId)
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	defer rows.Close()
+	queue := models.FoodQueue{}
+	for rows.Next() {
+		var item models.QueueItem
+		if err := rows.Scan(&item.FoodID, &item.Served); err != nil {
+			return nil, storageError(op, err)
+		}
+		queue = append(queue, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, storageError(op, err)
+	}
+	return queue, nil
+}
+
+// SaveQueue заменяет очередь блюд пользователя userId
+func (s *Storage) SaveQueue(userId int64, queue models.FoodQueue) error {
+	const op = "storagesqlite.SaveQueue"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storageError(op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM food_queue WHERE userId=?", userId); err != nil {
+		return storageError(op, err)
+	}
+	for i, item := range queue {
+		_, err := tx.Exec(
+			"INSERT INTO food_queue(userId, position, foodId, served) VALUES(?, ?, ?, ?)",
+			userId, i, item.FoodID, item.Served,
+		)
+		if err != nil {
+			return storageError(op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
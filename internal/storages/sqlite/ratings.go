@@ -0,0 +1,69 @@
+package storagesqlite
+
+import (
+	"database/sql"
+	"dinner/internal/storages"
+	"errors"
+)
+
+// GetRatings отдает оценки блюд пользователя userId по id блюда
+func (s *Storage) GetRatings(userId int64) (map[int64]int, error) {
+	const op = "storagesqlite.GetRatings"
+
+	rows, err := s.db.Query("SELECT foodId, rating FROM food_ratings WHERE userId=?", userId)
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	defer rows.Close()
+	ratings := make(map[int64]int)
+	for rows.Next() {
+		var foodId int64
+		var rating int
+		if err := rows.Scan(&foodId, &rating); err != nil {
+			return nil, storageError(op, err)
+		}
+		ratings[foodId] = rating
+	}
+	if err := rows.Err(); err != nil {
+		return nil, storageError(op, err)
+	}
+	return ratings, nil
+}
+
+// SetRating сохраняет оценку блюда foodId от пользователя userId.
+// Нулевая оценка удаляет оценку блюда.
+func (s *Storage) SetRating(userId int64, foodId int64, rating int) error {
+	const op = "storagesqlite.SetRating"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storageError(op, err)
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRow("SELECT id FROM foods WHERE id=?", foodId).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storages.ErrFoodNotFound
+	}
+	if err != nil {
+		return storageError(op, err)
+	}
+	if rating == 0 {
+		_, err = tx.Exec("DELETE FROM food_ratings WHERE userId=? AND foodId=?", userId, foodId)
+	} else {
+		_, err = tx.Exec(
+			`INSERT INTO food_ratings(userId, foodId, rating) VALUES(?, ?, ?)
+			ON CONFLICT(userId, foodId) DO UPDATE SET rating=excluded.rating`,
+			userId, foodId, rating,
+		)
+	}
+	if err != nil {
+		return storageError(op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
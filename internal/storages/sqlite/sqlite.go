@@ -3,9 +3,15 @@ package storagesqlite
 import (
 	"database/sql"
 	"dinner/internal/domain/models"
+	"dinner/internal/lib/metrics"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/mattn/go-sqlite3"
 )
 
 type Storage struct {
@@ -18,10 +24,15 @@ func New(log *slog.Logger, storagePath string) (*Storage, error) {
 	const op = "storage.sqlite.New"
 
 	fmt.Print()
-	// Указываем путь до файла БД
-	db, err := sql.Open("sqlite3", storagePath)
+	// Указываем путь до файла БД. Без _foreign_keys SQLite не проверяет внешние ключи
+	// и не выполняет ON DELETE CASCADE
+	dsn := storagePath + "?_foreign_keys=on"
+	if strings.Contains(storagePath, "?") {
+		dsn = storagePath + "&_foreign_keys=on"
+	}
+	db, err := sql.Open("sqlite3", dsn)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, storageError(op, err)
 	}
 
 	return &Storage{
@@ -30,52 +41,340 @@ func New(log *slog.Logger, storagePath string) (*Storage, error) {
 	}, nil
 }
 
-// GetFoods отдает список доступных блюд
+// storageError учитывает ошибку операции op в метриках и добавляет к ней op
+func storageError(op string, err error) error {
+	metrics.StorageErrors.WithLabelValues(op).Inc()
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+// Ping проверяет доступность БД
+func (s *Storage) Ping() error {
+	const op = "storagesqlite.Ping"
+
+	if err := s.db.Ping(); err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
+
+// GetFoods отдает список доступных блюд вместе с тегами, ингредиентами,
+// переводами, пищевой ценностью, сезонами, днями недели, фото и ценой
 func (s *Storage) GetFoods() ([]models.Food, error) {
 	const op = "storagesqlite.GetFoods"
 
-	rows, err := s.db.Query("SELECT name, category from foods")
+	rows, err := s.db.Query("SELECT id, name, category, recipe, photo, price from foods ORDER BY id")
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, storageError(op, err)
 	}
+	defer rows.Close()
 	foods := []models.Food{}
+	// Позиция блюда в foods по его id
+	positions := map[int64]int{}
 
 	for rows.Next() {
 		var food models.Food
-		_ = rows.Scan(&food.Name, &food.Category)
+		if err := rows.Scan(&food.ID, &food.Name, &food.Category, &food.Recipe, &food.Photo, &food.Price); err != nil {
+			return nil, storageError(op, err)
+		}
+		positions[food.ID] = len(foods)
 		foods = append(foods, food)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, storageError(op, err)
+	}
+
+	// Теги и ингредиенты
+	err = s.scanFoodValues("SELECT foodId, tag FROM food_tags ORDER BY tag", func(id int64, value string) {
+		if pos, ok := positions[id]; ok {
+			foods[pos].Tags = append(foods[pos].Tags, value)
+		}
+	})
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	err = s.scanFoodValues("SELECT foodId, ingredient FROM food_ingredients ORDER BY ingredient", func(id int64, value string) {
+		if pos, ok := positions[id]; ok {
+			foods[pos].Ingredients = append(foods[pos].Ingredients, value)
+		}
+	})
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+
+	// Сезоны и предпочтительные дни недели
+	err = s.scanFoodValues("SELECT foodId, season FROM food_seasons ORDER BY foodId", func(id int64, value string) {
+		pos, ok := positions[id]
+		season, known := models.ParseSeason(value)
+		if ok && known {
+			foods[pos].Seasons = append(foods[pos].Seasons, season)
+		}
+	})
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	err = s.scanFoodValues("SELECT foodId, weekday FROM food_weekdays ORDER BY foodId", func(id int64, value string) {
+		pos, ok := positions[id]
+		day, known := models.ParseWeekday(value)
+		if ok && known {
+			foods[pos].Weekdays = append(foods[pos].Weekdays, day)
+		}
+	})
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	for i := range foods {
+		slices.Sort(foods[i].Seasons)
+		slices.Sort(foods[i].Weekdays)
+	}
+
+	// Переводы названий
+	rows, err = s.db.Query("SELECT foodId, lang, name FROM food_names")
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var lang, name string
+		if err := rows.Scan(&id, &lang, &name); err != nil {
+			return nil, storageError(op, err)
+		}
+		if pos, ok := positions[id]; ok {
+			if foods[pos].Names == nil {
+				foods[pos].Names = map[string]string{}
+			}
+			foods[pos].Names[lang] = name
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, storageError(op, err)
+	}
+
+	// Пищевая ценность
+	rows, err = s.db.Query("SELECT foodId, kcal, protein, fat, carbs FROM food_nutrition")
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var nutrition models.Nutrition
+		if err := rows.Scan(&id, &nutrition.Kcal, &nutrition.Protein, &nutrition.Fat, &nutrition.Carbs); err != nil {
+			return nil, storageError(op, err)
+		}
+		if pos, ok := positions[id]; ok {
+			foods[pos].Nutrition = &nutrition
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, storageError(op, err)
+	}
 
 	return foods, nil
 }
 
-// SaveRequest сохраняет запрос юзера userId в историю
-func (s *Storage) SaveRequest(userId int64) error {
-	const op = "storagesqlite.SaveRequest"
+// scanFoodValues выполняет запрос query, возвращающий пары (id блюда, значение),
+// и передает каждую пару в add
+func (s *Storage) scanFoodValues(query string, add func(id int64, value string)) error {
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var value string
+		if err := rows.Scan(&id, &value); err != nil {
+			return err
+		}
+		add(id, value)
+	}
+	return rows.Err()
+}
+
+// GetCategories отдает список категорий блюд
+func (s *Storage) GetCategories() ([]models.Category, error) {
+	const op = "storagesqlite.GetCategories"
+
+	rows, err := s.db.Query("SELECT id, name FROM categories ORDER BY id")
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	defer rows.Close()
+	categories := []models.Category{}
+	for rows.Next() {
+		var category models.Category
+		if err := rows.Scan(&category.ID, &category.Name); err != nil {
+			return nil, storageError(op, err)
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, storageError(op, err)
+	}
+	return categories, nil
+}
 
-	stmt, err := s.db.Prepare("INSERT INTO history(userId, dt) VALUES(?, ?)")
+// SaveFoods добавляет или обновляет блюда по названию.
+// Теги, ингредиенты, переводы названий, пищевая ценность, сезоны и дни недели
+// сохраненных блюд заменяются переданными.
+func (s *Storage) SaveFoods(foods []models.Food) error {
+	const op = "storagesqlite.SaveFoods"
+
+	tx, err := s.db.Begin()
 	if err != nil {
-		s.log.Error("sql prepare", slog.Any("error", err))
-		return fmt.Errorf("%s: %w", op, err)
+		return storageError(op, err)
+	}
+	defer tx.Rollback()
+
+	for _, food := range foods {
+		_, err := tx.Exec(
+			`INSERT INTO foods(name, category, recipe) VALUES(?, ?, ?)
+			ON CONFLICT(name) DO UPDATE SET category=excluded.category, recipe=excluded.recipe`,
+			food.Name, food.Category, food.Recipe,
+		)
+		if err != nil {
+			return storageError(op, err)
+		}
+		var id int64
+		if err := tx.QueryRow("SELECT id FROM foods WHERE name=?", food.Name).Scan(&id); err != nil {
+			return storageError(op, err)
+		}
+		if err := saveFoodDetails(tx, id, food); err != nil {
+			return storageError(op, err)
+		}
 	}
-	_, errExec := stmt.Exec(userId, time.Now())
-	if errExec != nil {
-		s.log.Error("sql exec", slog.Any("error", errExec))
-		return fmt.Errorf("%s: %w", op, errExec)
+
+	if err := tx.Commit(); err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
+
+// SaveRequest сохраняет запрос юзера userId и предложенные ему блюда foods в историю
+func (s *Storage) SaveRequest(userId int64, foods []models.Food) error {
+	const op = "storagesqlite.SaveRequest"
+
+	if err := s.saveRequest(userId, foods, false); err != nil {
+		return storageError(op, err)
 	}
 	return nil
 }
 
-// IsLimit Проверяет превышен ли лимит запросов для юзера userId
+// SavePlanRequest сохраняет в историю запрос юзера userId на несколько ужинов сразу
+// (план, заготовку или варианты на выбор) и все их блюда foods
+func (s *Storage) SavePlanRequest(userId int64, foods []models.Food) error {
+	const op = "storagesqlite.SavePlanRequest"
+
+	if err := s.saveRequest(userId, foods, true); err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
+
+// saveRequest сохраняет запрос в историю, plan - запрос на несколько ужинов
+func (s *Storage) saveRequest(userId int64, foods []models.Food, plan bool) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("INSERT INTO history(userId, dt, plan) VALUES(?, ?, ?)", userId, time.Now(), plan)
+	if err != nil {
+		s.log.Error("sql exec", slog.Any("error", err))
+		return err
+	}
+	historyId, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for _, food := range foods {
+		_, err := tx.Exec("INSERT INTO history_foods(historyId, foodId, name, category) VALUES(?, ?, ?, ?)",
+			historyId, food.ID, food.Name, food.Category)
+		if err != nil {
+			s.log.Error("sql exec", slog.Any("error", err))
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetHistory отдает историю запросов юзера userId в порядке их выполнения.
+// Блюда, удаленные из каталога, остаются в истории с названием и категорией на момент запроса.
+func (s *Storage) GetHistory(userId int64) ([]models.HistoryEntry, error) {
+	const op = "storagesqlite.GetHistory"
+
+	rows, err := s.db.Query(`SELECT h.id, h.dt, h.plan, hf.foodId, COALESCE(f.name, hf.name), COALESCE(f.category, hf.category)
+		FROM history h
+		LEFT JOIN history_foods hf ON hf.historyId = h.id
+		LEFT JOIN foods f ON f.id = hf.foodId
+		WHERE h.userId = ?
+		ORDER BY h.id, hf.rowid`, userId)
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	defer rows.Close()
+
+	history := []models.HistoryEntry{}
+	var lastId int64
+	for rows.Next() {
+		var id int64
+		var dt string
+		var plan bool
+		var foodId sql.NullInt64
+		var foodName sql.NullString
+		var foodCategory sql.NullInt64
+		if err := rows.Scan(&id, &dt, &plan, &foodId, &foodName, &foodCategory); err != nil {
+			return nil, storageError(op, err)
+		}
+		if len(history) == 0 || id != lastId {
+			t, err := parseTime(dt)
+			if err != nil {
+				return nil, storageError(op, err)
+			}
+			history = append(history, models.HistoryEntry{Time: t, Plan: plan})
+			lastId = id
+		}
+		if foodId.Valid {
+			entry := &history[len(history)-1]
+			entry.Foods = append(entry.Foods, models.Food{
+				ID:       foodId.Int64,
+				Name:     foodName.String,
+				Category: models.FootCategory(foodCategory.Int64),
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, storageError(op, err)
+	}
+	return history, nil
+}
+
+// parseTime разбирает время, сохраненное драйвером SQLite в текстовую колонку
+func parseTime(value string) (time.Time, error) {
+	var err error
+	for _, layout := range sqlite3.SQLiteTimestampFormats {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// IsLimit Проверяет превышен ли лимит запросов для юзера userId.
+// Запросы до сброса лимита администратором не учитываются.
 func (s *Storage) IsLimit(userId int64) (bool, error) {
 	const op = "storagesqlite.IsLimit"
 
-	stmt, err := s.db.Prepare("SELECT count(userId) FROM history WHERE userId==? AND dt>=?")
+	stmt, err := s.db.Prepare(`SELECT count(userId) FROM history WHERE userId==? AND dt>=?
+		AND dt>=COALESCE((SELECT limitResetAt FROM users WHERE id==?), '')`)
 	if err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
+		return false, storageError(op, err)
 	}
 
-	row := stmt.QueryRow(userId, time.Now().Add((-1)*time.Duration(24)*time.Hour))
+	row := stmt.QueryRow(userId, time.Now().Add((-1)*time.Duration(24)*time.Hour), userId)
 
 	if row == nil {
 		return true, nil
@@ -86,3 +385,153 @@ func (s *Storage) IsLimit(userId int64) (bool, error) {
 
 	return cnt < 10, nil
 }
+
+// ApplySeed добавляет или обновляет категории и блюда из начальных данных.
+// Категории сопоставляются по id, блюда - по названию.
+// Блюда, которых нет в начальных данных, не удаляются.
+func (s *Storage) ApplySeed(seed models.Seed) (models.SeedReport, error) {
+	const op = "storagesqlite.ApplySeed"
+
+	report := models.SeedReport{}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return report, storageError(op, err)
+	}
+	defer tx.Rollback()
+
+	for _, category := range seed.Categories {
+		var name string
+		err := tx.QueryRow("SELECT name FROM categories WHERE id=?", category.ID).Scan(&name)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			if _, err := tx.Exec("INSERT INTO categories(id, name) VALUES(?, ?)", category.ID, category.Name); err != nil {
+				return report, storageError(op, err)
+			}
+			report.Added = append(report.Added, "category "+category.Name)
+		case err != nil:
+			return report, storageError(op, err)
+		case name != category.Name:
+			if _, err := tx.Exec("UPDATE categories SET name=? WHERE id=?", category.Name, category.ID); err != nil {
+				return report, storageError(op, err)
+			}
+			report.Updated = append(report.Updated, "category "+category.Name)
+		default:
+			report.Unchanged++
+		}
+	}
+
+	for _, food := range seed.Foods {
+		var id int64
+		var category models.FootCategory
+		err := tx.QueryRow("SELECT id, category FROM foods WHERE name=?", food.Name).Scan(&id, &category)
+		added, changed := false, false
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			added = true
+			res, err := tx.Exec("INSERT INTO foods(name, category) VALUES(?, ?)", food.Name, food.Category)
+			if err != nil {
+				return report, storageError(op, err)
+			}
+			if id, err = res.LastInsertId(); err != nil {
+				return report, storageError(op, err)
+			}
+		case err != nil:
+			return report, storageError(op, err)
+		case category != food.Category:
+			if _, err := tx.Exec("UPDATE foods SET category=? WHERE id=?", food.Category, id); err != nil {
+				return report, storageError(op, err)
+			}
+			changed = true
+		}
+
+		// Переводы названия: добавляем и обновляем только указанные языки
+		for lang, name := range food.Names {
+			var current string
+			err := tx.QueryRow("SELECT name FROM food_names WHERE foodId=? AND lang=?", id, lang).Scan(&current)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return report, storageError(op, err)
+			}
+			if current == name {
+				continue
+			}
+			_, err = tx.Exec("INSERT INTO food_names(foodId, lang, name) VALUES(?, ?, ?) ON CONFLICT(foodId, lang) DO UPDATE SET name=excluded.name", id, lang, name)
+			if err != nil {
+				return report, storageError(op, err)
+			}
+			changed = true
+		}
+
+		// Ингредиенты, пищевую ценность, сезоны и дни недели заменяем,
+		// только если они указаны в начальных данных. Ингредиенты сохраняются
+		// в том же виде, что и при импорте каталога, иначе выгрузка и импорт покажут различия
+		if ingredients := models.NormalizeList(food.Ingredients); len(ingredients) > 0 {
+			replaced, err := replaceFoodValues(tx, "food_ingredients", "ingredient", id, ingredients)
+			if err != nil {
+				return report, storageError(op, err)
+			}
+			changed = changed || replaced
+		}
+		if food.Nutrition != nil {
+			replaced, err := replaceNutrition(tx, id, *food.Nutrition)
+			if err != nil {
+				return report, storageError(op, err)
+			}
+			changed = changed || replaced
+		}
+		if len(food.Seasons) > 0 {
+			replaced, err := replaceFoodValues(tx, "food_seasons", "season", id, seasonValues(food.Seasons))
+			if err != nil {
+				return report, storageError(op, err)
+			}
+			changed = changed || replaced
+		}
+		if len(food.Weekdays) > 0 {
+			replaced, err := replaceFoodValues(tx, "food_weekdays", "weekday", id, weekdayValues(food.Weekdays))
+			if err != nil {
+				return report, storageError(op, err)
+			}
+			changed = changed || replaced
+		}
+
+		switch {
+		case added:
+			report.Added = append(report.Added, "food "+food.Name)
+		case changed:
+			report.Updated = append(report.Updated, "food "+food.Name)
+		default:
+			report.Unchanged++
+		}
+	}
+
+	for _, pairing := range seed.Pairings {
+		var meatId, sideId int64
+		if err := tx.QueryRow("SELECT id FROM foods WHERE name=?", pairing.Meat).Scan(&meatId); err != nil {
+			return report, storageError(op, fmt.Errorf("pairing meat %q: %w", pairing.Meat, err))
+		}
+		if err := tx.QueryRow("SELECT id FROM foods WHERE name=?", pairing.Side).Scan(&sideId); err != nil {
+			return report, storageError(op, fmt.Errorf("pairing side %q: %w", pairing.Side, err))
+		}
+		var exists bool
+		err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM food_pairings WHERE meatId=? AND sideId=?)", meatId, sideId).Scan(&exists)
+		if err != nil {
+			return report, storageError(op, err)
+		}
+		replaced, err := replacePairing(tx, models.Pairing{MeatID: meatId, SideID: sideId, Weight: pairing.Weight})
+		if err != nil {
+			return report, storageError(op, err)
+		}
+		switch {
+		case replaced && !exists:
+			report.Added = append(report.Added, "pairing "+pairing.Meat+" + "+pairing.Side)
+		case replaced:
+			report.Updated = append(report.Updated, "pairing "+pairing.Meat+" + "+pairing.Side)
+		default:
+			report.Unchanged++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return report, storageError(op, err)
+	}
+	return report, nil
+}
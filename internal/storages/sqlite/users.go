@@ -0,0 +1,270 @@
+package storagesqlite
+
+import (
+	"database/sql"
+	"dinner/internal/domain/models"
+	"dinner/internal/storages"
+	"errors"
+	"time"
+)
+
+// SaveUser добавляет пользователя или обновляет его чат, имя и время последней активности
+func (s *Storage) SaveUser(user models.User) error {
+	const op = "storagesqlite.SaveUser"
+
+	_, err := s.db.Exec(`INSERT INTO users(id, chatId, userName, firstSeen, lastSeen) VALUES(?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET chatId=excluded.chatId, userName=excluded.userName, lastSeen=excluded.lastSeen`,
+		user.ID, user.ChatID, user.UserName, user.LastSeen, user.LastSeen,
+	)
+	if err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
+
+// IsBanned проверяет, заблокирован ли пользователь userId
+func (s *Storage) IsBanned(userId int64) (bool, error) {
+	const op = "storagesqlite.IsBanned"
+
+	var banned bool
+	err := s.db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE id=? AND banned=1)", userId).Scan(&banned)
+	if err != nil {
+		return false, storageError(op, err)
+	}
+	return banned, nil
+}
+
+// SetBanned блокирует или разблокирует пользователя userId
+func (s *Storage) SetBanned(userId int64, banned bool) error {
+	const op = "storagesqlite.SetBanned"
+
+	res, err := s.db.Exec("UPDATE users SET banned=? WHERE id=?", banned, userId)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrUserNotFound
+	}
+	return nil
+}
+
+// ResetLimit сбрасывает лимит запросов пользователя userId на момент t
+func (s *Storage) ResetLimit(userId int64, t time.Time) error {
+	const op = "storagesqlite.ResetLimit"
+
+	res, err := s.db.Exec("UPDATE users SET limitResetAt=? WHERE id=?", t, userId)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrUserNotFound
+	}
+	return nil
+}
+
+// GetUsers отдает всех известных пользователей бота
+func (s *Storage) GetUsers() ([]models.User, error) {
+	const op = "storagesqlite.GetUsers"
+
+	rows, err := s.db.Query("SELECT id, chatId, userName, lastSeen, banned FROM users ORDER BY id")
+	if err != nil {
+		return nil, storageError(op, err)
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		var user models.User
+		var lastSeen string
+		if err := rows.Scan(&user.ID, &user.ChatID, &user.UserName, &lastSeen, &user.Banned); err != nil {
+			return nil, storageError(op, err)
+		}
+		if user.LastSeen, err = parseTime(lastSeen); err != nil {
+			return nil, storageError(op, err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, storageError(op, err)
+	}
+	return users, nil
+}
+
+// SaveAudit сохраняет действие администратора в журнал
+func (s *Storage) SaveAudit(entry models.AuditEntry) error {
+	const op = "storagesqlite.SaveAudit"
+
+	_, err := s.db.Exec("INSERT INTO admin_audit(adminId, action, args, dt) VALUES(?, ?, ?, ?)",
+		entry.AdminID, entry.Action, entry.Args, entry.Time,
+	)
+	if err != nil {
+		return storageError(op, err)
+	}
+	return nil
+}
+
+// GetUserLang отдает язык, выбранный пользователем userId, или пустую строку
+func (s *Storage) GetUserLang(userId int64) (string, error) {
+	const op = "storagesqlite.GetUserLang"
+
+	var lang string
+	err := s.db.QueryRow("SELECT lang FROM users WHERE id=?", userId).Scan(&lang)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", storageError(op, err)
+	}
+	return lang, nil
+}
+
+// SetUserLang сохраняет язык, выбранный пользователем userId.
+// Пустая строка означает язык клиента Telegram.
+func (s *Storage) SetUserLang(userId int64, lang string) error {
+	const op = "storagesqlite.SetUserLang"
+
+	res, err := s.db.Exec("UPDATE users SET lang=? WHERE id=?", lang, userId)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrUserNotFound
+	}
+	return nil
+}
+
+// GetKcalTarget отдает диапазон калорий ужина, заданный пользователем userId
+func (s *Storage) GetKcalTarget(userId int64) (models.KcalRange, error) {
+	const op = "storagesqlite.GetKcalTarget"
+
+	var target models.KcalRange
+	err := s.db.QueryRow("SELECT kcalMin, kcalMax FROM users WHERE id=?", userId).Scan(&target.Min, &target.Max)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.KcalRange{}, nil
+	}
+	if err != nil {
+		return models.KcalRange{}, storageError(op, err)
+	}
+	return target, nil
+}
+
+// SetKcalTarget сохраняет диапазон калорий ужина пользователя userId.
+// Пустой диапазон снимает ограничение.
+func (s *Storage) SetKcalTarget(userId int64, target models.KcalRange) error {
+	const op = "storagesqlite.SetKcalTarget"
+
+	res, err := s.db.Exec("UPDATE users SET kcalMin=?, kcalMax=? WHERE id=?", target.Min, target.Max, userId)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrUserNotFound
+	}
+	return nil
+}
+
+// GetBudget отдает бюджет пользователя userId на ужины за неделю в рублях, 0 - не задан
+func (s *Storage) GetBudget(userId int64) (int, error) {
+	const op = "storagesqlite.GetBudget"
+
+	var budget int
+	err := s.db.QueryRow("SELECT weeklyBudget FROM users WHERE id=?", userId).Scan(&budget)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, storageError(op, err)
+	}
+	return budget, nil
+}
+
+// SetBudget сохраняет бюджет пользователя userId на ужины за неделю.
+// 0 снимает ограничение.
+func (s *Storage) SetBudget(userId int64, budget int) error {
+	const op = "storagesqlite.SetBudget"
+
+	res, err := s.db.Exec("UPDATE users SET weeklyBudget=? WHERE id=?", budget, userId)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrUserNotFound
+	}
+	return nil
+}
+
+// GetTimezone отдает часовой пояс пользователя userId (например, "Europe/Moscow")
+// или пустую строку, если он не задан
+func (s *Storage) GetTimezone(userId int64) (string, error) {
+	const op = "storagesqlite.GetTimezone"
+
+	var timezone string
+	err := s.db.QueryRow("SELECT timezone FROM users WHERE id=?", userId).Scan(&timezone)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", storageError(op, err)
+	}
+	return timezone, nil
+}
+
+// SetTimezone сохраняет часовой пояс пользователя userId.
+// Пустая строка означает часовой пояс сервера.
+func (s *Storage) SetTimezone(userId int64, timezone string) error {
+	const op = "storagesqlite.SetTimezone"
+
+	res, err := s.db.Exec("UPDATE users SET timezone=? WHERE id=?", timezone, userId)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrUserNotFound
+	}
+	return nil
+}
+
+// GetMode отдает стратегию выбора ужина пользователя userId
+// или пустую строку, если она не задана
+func (s *Storage) GetMode(userId int64) (string, error) {
+	const op = "storagesqlite.GetMode"
+
+	var mode string
+	err := s.db.QueryRow("SELECT mode FROM users WHERE id=?", userId).Scan(&mode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", storageError(op, err)
+	}
+	return mode, nil
+}
+
+// SetMode сохраняет стратегию выбора ужина пользователя userId.
+// Пустая строка означает стратегию по умолчанию.
+func (s *Storage) SetMode(userId int64, mode string) error {
+	const op = "storagesqlite.SetMode"
+
+	res, err := s.db.Exec("UPDATE users SET mode=? WHERE id=?", mode, userId)
+	if err != nil {
+		return storageError(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return storageError(op, err)
+	} else if n == 0 {
+		return storages.ErrUserNotFound
+	}
+	return nil
+}
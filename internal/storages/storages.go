@@ -0,0 +1,17 @@
+package storages
+
+import "errors"
+
+// Ошибки на уровне хранилища
+var (
+	// Пользователь не найден
+	ErrUserNotFound = errors.New("user not found")
+	// Блюдо не найдено
+	ErrFoodNotFound = errors.New("food not found")
+	// Блюдо с таким названием уже есть
+	ErrFoodExists = errors.New("food already exists")
+	// Семья не найдена
+	ErrHouseholdNotFound = errors.New("household not found")
+	// Пользователь уже состоит в семье
+	ErrAlreadyInHousehold = errors.New("user is already in a household")
+)
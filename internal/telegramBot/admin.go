@@ -0,0 +1,150 @@
+package telegrambot
+
+import (
+	"dinner/internal/lib/i18n"
+	"dinner/internal/services"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Интервал между сообщениями рассылки.
+// Telegram разрешает боту около 30 сообщений в секунду, оставляем запас.
+const broadcastInterval = time.Second / 25
+
+// UsersCommand отправляет администратору количество пользователей бота
+func (b *TelegramBot) UsersCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.UsersCommand"
+	log := b.log.With(slog.String("op", op))
+
+	count, err := b.admin.CountUsers(message.From.ID)
+	if err != nil {
+		log.Error("count users error", slog.Any("error", err))
+		return err
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(b.lang(message), i18n.UsersCount, count.Total, count.Active, count.Banned))
+	return nil
+}
+
+// BroadcastCommand рассылает текст команды всем пользователям бота: /broadcast <текст>.
+// Рассылка идет в фоне с ограничением частоты, по завершении администратор получает отчет.
+func (b *TelegramBot) BroadcastCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.BroadcastCommand"
+	log := b.log.With(slog.String("op", op))
+
+	text := strings.TrimSpace(message.CommandArguments())
+	if text == "" {
+		if b.admin.IsAdmin(message.From.ID) {
+			b.sendText(bot, message.Chat.ID, i18n.T(b.lang(message), i18n.BroadcastUsage))
+		}
+		return nil
+	}
+	chats, err := b.admin.BroadcastTargets(message.From.ID, text)
+	if err != nil {
+		log.Error("broadcast error", slog.Any("error", err))
+		return err
+	}
+
+	lang := b.lang(message)
+	b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.BroadcastStarted, len(chats)))
+	go func() {
+		sent := b.broadcast(bot, chats, text)
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.BroadcastDone, sent, len(chats)))
+	}()
+	return nil
+}
+
+// broadcast отправляет text в чаты chats не чаще одного сообщения за broadcastInterval
+// и отдает количество доставленных сообщений.
+// При ответе Telegram "Too Many Requests" ждет указанное время и повторяет отправку.
+func (b *TelegramBot) broadcast(bot *tgbotapi.BotAPI, chats []int64, text string) int {
+	const op = "TelegramBot.broadcast"
+	log := b.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(broadcastInterval)
+	defer ticker.Stop()
+
+	sent := 0
+	for _, chatID := range chats {
+		<-ticker.C
+		_, err := b.send(bot, tgbotapi.NewMessage(chatID, text))
+		var tgErr *tgbotapi.Error
+		if errors.As(err, &tgErr) && tgErr.RetryAfter > 0 {
+			time.Sleep(time.Duration(tgErr.RetryAfter) * time.Second)
+			_, err = b.send(bot, tgbotapi.NewMessage(chatID, text))
+		}
+		if err != nil {
+			log.Warn("broadcast message error", slog.Int64("chat", chatID), slog.Any("error", err))
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+// ResetLimitCommand сбрасывает лимит запросов пользователя: /resetlimit <userId>.
+// У участника семьи сбрасывается общий лимит семьи.
+func (b *TelegramBot) ResetLimitCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	userId, ok := b.userIdArgument(bot, message)
+	if !ok {
+		return nil
+	}
+	err := b.admin.ResetLimit(message.From.ID, b.households.Account(userId))
+	return b.replyAdminResult(bot, message, err, i18n.LimitReset)
+}
+
+// BanCommand блокирует пользователя: /ban <userId>
+func (b *TelegramBot) BanCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	userId, ok := b.userIdArgument(bot, message)
+	if !ok {
+		return nil
+	}
+	err := b.admin.SetBanned(message.From.ID, userId, true)
+	return b.replyAdminResult(bot, message, err, i18n.UserBanned)
+}
+
+// UnbanCommand разблокирует пользователя: /unban <userId>
+func (b *TelegramBot) UnbanCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	userId, ok := b.userIdArgument(bot, message)
+	if !ok {
+		return nil
+	}
+	err := b.admin.SetBanned(message.From.ID, userId, false)
+	return b.replyAdminResult(bot, message, err, i18n.UserUnbanned)
+}
+
+// userIdArgument разбирает id пользователя из аргумента команды.
+// Администратору при ошибке отправляется подсказка.
+func (b *TelegramBot) userIdArgument(bot *tgbotapi.BotAPI, message *tgbotapi.Message) (int64, bool) {
+	userId, err := strconv.ParseInt(strings.TrimSpace(message.CommandArguments()), 10, 64)
+	if err != nil {
+		if b.admin.IsAdmin(message.From.ID) {
+			b.sendText(bot, message.Chat.ID, i18n.T(b.lang(message), i18n.UserIdUsage, message.Command()))
+		}
+		return 0, false
+	}
+	return userId, true
+}
+
+// replyAdminResult сообщает администратору результат команды
+func (b *TelegramBot) replyAdminResult(bot *tgbotapi.BotAPI, message *tgbotapi.Message, err error, success i18n.Key) error {
+	lang := b.lang(message)
+	switch {
+	case err == nil:
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, success))
+	case errors.Is(err, services.ErrUserNotFound):
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.UserNotFound))
+	case errors.Is(err, services.ErrAccessDenied):
+		// Не администраторам не отвечаем, чтобы не раскрывать команды
+		if b.admin.IsAdmin(message.From.ID) {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.ActionDenied))
+		}
+	default:
+		b.log.Error("admin command error", slog.String("command", message.Command()), slog.Any("error", err))
+	}
+	return err
+}
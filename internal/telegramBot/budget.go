@@ -0,0 +1,121 @@
+package telegrambot
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/i18n"
+	"dinner/internal/services"
+	adminservice "dinner/internal/services/admin"
+	dinnerservice "dinner/internal/services/dinner"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// BudgetCommand показывает или меняет бюджет на ужины за неделю: /budget 3500, /budget off.
+// С бюджетом /dinner предпочитает ужины не дороже бюджета одного ужина.
+// Бюджет общий для семьи (/household) и хранится от имени владельца, как история и лимит.
+func (b *TelegramBot) BudgetCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.BudgetCommand"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.lang(message)
+	account := b.households.Account(message.From.ID)
+	args := strings.ToLower(strings.TrimSpace(message.CommandArguments()))
+	if args == "" {
+		budget := b.prefs.Budget(account)
+		if budget == 0 {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.BudgetNotSet)+"\n"+i18n.T(lang, i18n.BudgetUsage))
+			return nil
+		}
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.BudgetCurrent, budget, budget/dinnerservice.DaysPerWeek))
+		return nil
+	}
+
+	budget := 0
+	if args != "off" {
+		var err error
+		if budget, err = strconv.Atoi(args); err != nil || budget <= 0 {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.BudgetUsage))
+			return nil
+		}
+	}
+	if err := b.prefs.SetBudget(account, budget); err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidBudget):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.BudgetUsage))
+			return nil
+		case errors.Is(err, services.ErrUserNotFound):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.UserNotFound))
+		}
+		log.Error("set budget error", slog.Any("error", err))
+		return err
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.BudgetChanged))
+	return nil
+}
+
+// PriceCommand меняет примерную цену порции блюда или ингредиента (для администраторов):
+// /price Борщ 250, /price курица 120, /price курица 0 - удалить цену.
+func (b *TelegramBot) PriceCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.PriceCommand"
+	log := b.log.With(slog.String("op", op))
+
+	if !b.admin.IsAdmin(message.From.ID) {
+		return services.ErrAccessDenied
+	}
+	lang := b.lang(message)
+	args := strings.TrimSpace(message.CommandArguments())
+	i := strings.LastIndex(args, " ")
+	if i < 0 {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PriceUsage))
+		return nil
+	}
+	name := strings.TrimSpace(args[:i])
+	price, err := strconv.Atoi(args[i+1:])
+	if err != nil {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PriceUsage))
+		return nil
+	}
+	if err := b.admin.Audit(message.From.ID, adminservice.ActionPrice, name+" "+strconv.Itoa(price)); err != nil {
+		return err
+	}
+	food, isFood, err := b.catalog.SetPrice(name, price)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidPrice):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PriceUsage))
+			return nil
+		case errors.Is(err, services.ErrFoodNotFound):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PriceUnknown, name))
+			return nil
+		}
+		log.Error("set price error", slog.Any("error", err))
+		return err
+	}
+	if isFood {
+		name = i18n.FoodName(lang, food)
+	}
+	if price == 0 {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PriceRemoved, name))
+		return nil
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PriceSaved, name, price))
+	return nil
+}
+
+// isCheap проверяет, что пользователь просит дешевый ужин: /dinner cheap
+func isCheap(args string) bool {
+	return hasArg(args, "cheap", "дешево", "дёшево", "дешевый", "дешёвый")
+}
+
+// prices отдает цены ингредиентов, при ошибке стоимость считается только по ценам блюд
+func (b *TelegramBot) prices() models.Prices {
+	prices, err := b.catalog.Prices()
+	if err != nil {
+		b.log.Error("get prices error", slog.Any("error", err))
+	}
+	return prices
+}
@@ -0,0 +1,139 @@
+package telegrambot
+
+import (
+	"bytes"
+	"dinner/internal/lib/i18n"
+	"dinner/internal/services"
+	adminservice "dinner/internal/services/admin"
+	catalogservice "dinner/internal/services/catalog"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Максимальный размер загружаемого файла каталога
+const maxCatalogSize = 1 << 20
+
+// CatalogCommand отправляет администратору каталог блюд файлом.
+// Формат передается аргументом команды: /catalog csv|json|yaml (по умолчанию csv).
+func (b *TelegramBot) CatalogCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.CatalogCommand"
+	log := b.log.With(slog.String("op", op))
+
+	if !b.admin.IsAdmin(message.From.ID) {
+		return services.ErrAccessDenied
+	}
+
+	format := catalogservice.FormatCSV
+	if args := strings.TrimSpace(message.CommandArguments()); args != "" {
+		var err error
+		format, err = catalogservice.ParseFormat(args)
+		if err != nil {
+			b.sendText(bot, message.Chat.ID, i18n.T(b.lang(message), i18n.UnknownFormat))
+			return err
+		}
+	}
+
+	if err := b.admin.Audit(message.From.ID, adminservice.ActionCatalogExport, string(format)); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := b.catalog.Export(format, &buf); err != nil {
+		log.Error("export catalog error", slog.Any("error", err))
+		return err
+	}
+	doc := tgbotapi.NewDocument(message.Chat.ID, tgbotapi.FileBytes{
+		Name:  "foods." + string(format),
+		Bytes: buf.Bytes(),
+	})
+	if _, err := b.send(bot, doc); err != nil {
+		log.Error("send document error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+// ImportCommand загружает каталог блюд из присланного администратором файла.
+// Подпись к файлу "/import" показывает изменения без сохранения,
+// "/import apply" сохраняет их.
+func (b *TelegramBot) ImportCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.ImportCommand"
+	log := b.log.With(slog.String("op", op))
+
+	if !b.admin.IsAdmin(message.From.ID) {
+		return services.ErrAccessDenied
+	}
+	if message.Document == nil {
+		b.sendText(bot, message.Chat.ID, i18n.T(b.lang(message), i18n.ImportHint))
+		return nil
+	}
+	args := strings.Fields(message.Caption)
+	dryRun := !(len(args) > 1 && args[1] == "apply")
+	if !dryRun {
+		if err := b.admin.Audit(message.From.ID, adminservice.ActionCatalogImport, message.Document.FileName); err != nil {
+			return err
+		}
+	}
+
+	format, err := catalogservice.ParseFormat(message.Document.FileName)
+	if err != nil {
+		b.sendText(bot, message.Chat.ID, i18n.T(b.lang(message), i18n.UnknownFormat))
+		return err
+	}
+	if message.Document.FileSize > maxCatalogSize {
+		b.sendText(bot, message.Chat.ID, i18n.T(b.lang(message), i18n.FileTooLarge))
+		return fmt.Errorf("%s: file too large: %d", op, message.Document.FileSize)
+	}
+
+	data, err := downloadFile(bot, message.Document.FileID)
+	if err != nil {
+		log.Error("download file error", slog.Any("error", err))
+		return err
+	}
+
+	diff, err := b.catalog.Import(format, bytes.NewReader(data), dryRun)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCatalog) {
+			b.sendText(bot, message.Chat.ID, i18n.T(b.lang(message), i18n.FileError, err.Error()))
+		}
+		log.Error("import catalog error", slog.Any("error", err))
+		return err
+	}
+
+	text := catalogservice.DiffText(diff)
+	if dryRun {
+		text += "\n\n" + i18n.T(b.lang(message), i18n.ImportDryRun)
+	}
+	b.sendText(bot, message.Chat.ID, text)
+	return nil
+}
+
+// downloadFile скачивает файл fileID с серверов Telegram
+func downloadFile(bot *tgbotapi.BotAPI, fileID string) ([]byte, error) {
+	url, err := bot.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download file: %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxCatalogSize))
+}
+
+// sendText отправляет текстовое сообщение в чат chatID
+func (b *TelegramBot) sendText(bot *tgbotapi.BotAPI, chatID int64, text string) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	if _, err := b.send(bot, msg); err != nil {
+		b.log.Error("send message error", slog.Attr{Key: "error", Value: slog.StringValue(err.Error())})
+	}
+}
@@ -0,0 +1,68 @@
+package telegrambot
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/formatter"
+	"dinner/internal/lib/i18n"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Сколько ужинов показывать в ответе на /cook
+const cookLimit = 5
+
+// CookCommand подбирает ужины из продуктов, перечисленных через запятую:
+// /cook курица, рис, лук
+func (b *TelegramBot) CookCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.CookCommand"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.lang(message)
+	have := splitProducts(message.CommandArguments())
+	if len(have) == 0 {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.CookUsage))
+		return nil
+	}
+	options, err := b.dinner.Cook(have, cookLimit)
+	if err != nil {
+		log.Error("cook error", slog.Any("error", err))
+		return err
+	}
+	b.sendText(bot, message.Chat.ID, cookText(lang, options))
+	return nil
+}
+
+// splitProducts разбирает список продуктов через запятую, точку с запятой или с новой строки
+func splitProducts(args string) []string {
+	items := strings.FieldsFunc(args, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	})
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// cookText формирует текст с подобранными ужинами на языке lang
+func cookText(lang i18n.Lang, options []models.CookOption) string {
+	if len(options) == 0 {
+		return i18n.T(lang, i18n.CookNothing)
+	}
+	plain := formatter.New(formatter.Plain)
+	var sb strings.Builder
+	for i, option := range options {
+		fmt.Fprintf(&sb, "%d. %s - %d%%\n", i+1, plain.Dinner(lang, option.Foods), int(option.Coverage*100))
+		if len(option.Missing) == 0 {
+			sb.WriteString("   " + i18n.T(lang, i18n.CookComplete) + "\n")
+		} else {
+			sb.WriteString("   " + i18n.T(lang, i18n.CookMissing, strings.Join(option.Missing, ", ")) + "\n")
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,86 @@
+package telegrambot
+
+import (
+	"dinner/internal/lib/i18n"
+	"dinner/internal/lib/metrics"
+	"dinner/internal/services"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Начало данных кнопки с найденным блюдом, дальше - id блюда
+const pickCallbackPrefix = "pick:"
+
+// Сколько блюд показывает /find
+const findLimit = 10
+
+// FindCommand ищет блюда в каталоге: /find курица.
+// Найденные блюда показываются кнопками, нажатие составляет ужин вокруг блюда.
+func (b *TelegramBot) FindCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.FindCommand"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.lang(message)
+	query := strings.TrimSpace(message.CommandArguments())
+	if query == "" {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.FindUsage))
+		return nil
+	}
+	foods, err := b.catalog.Search(query, findLimit)
+	if err != nil {
+		log.Error("search foods error", slog.Any("error", err))
+		return err
+	}
+	if len(foods) == 0 {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.FindNothing, query))
+		return nil
+	}
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(foods))
+	for _, food := range foods {
+		data := pickCallbackPrefix + strconv.FormatInt(food.ID, 10)
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.FoodName(lang, food), data),
+		))
+	}
+	msg := tgbotapi.NewMessage(message.Chat.ID, i18n.T(lang, i18n.FindResults, len(foods)))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if _, err := b.send(bot, msg); err != nil {
+		log.Error("send message error", slog.Any("error", err))
+	}
+	return nil
+}
+
+// pickCallback составляет ужин вокруг блюда, выбранного кнопкой из /find
+func (b *TelegramBot) pickCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, value string) {
+	const op = "TelegramBot.pickCallback"
+	log := b.log.With(slog.String("op", op))
+
+	foodId, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Error("parse food id error", slog.String("data", query.Data), slog.Any("error", err))
+		return
+	}
+	chatID := query.Message.Chat.ID
+	lang := b.prefs.Language(query.From.ID, query.From.LanguageCode)
+	opts := b.dinnerOptions(query.From.ID)
+	foods, trace, err := b.dinner.WithSeed(b.dinner.NextSeed()).GetDinnerWith(b.households.Account(query.From.ID), foodId, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrFoodNotFound):
+			b.sendText(bot, chatID, i18n.T(lang, i18n.FindGone))
+		case errors.Is(err, services.ErrAttemptLimitExceeded):
+			metrics.QuotaRejections.Inc()
+			b.sendText(bot, chatID, i18n.T(lang, i18n.LimitExceeded))
+		default:
+			log.Error("get dinner with food error", slog.Any("error", err))
+		}
+		metrics.CommandsHandled.WithLabelValues("pick", "error").Inc()
+		return
+	}
+	b.sendDinner(bot, chatID, lang, foods, opts.Prices, trace)
+	metrics.CommandsHandled.WithLabelValues("pick", "ok").Inc()
+}
@@ -0,0 +1,193 @@
+package telegrambot
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/i18n"
+	"dinner/internal/services"
+	householdservice "dinner/internal/services/household"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// StartCommand приветствует пользователя. Ссылка-приглашение в семью
+// открывает бота с командой /start join_<код>.
+func (b *TelegramBot) StartCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	args := strings.TrimSpace(message.CommandArguments())
+	if code, ok := strings.CutPrefix(args, householdservice.JoinPrefix); ok {
+		return b.joinHousehold(bot, message, code)
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(b.lang(message), i18n.StartWelcome))
+	return nil
+}
+
+// joinHousehold добавляет пользователя в семью по коду приглашения
+func (b *TelegramBot) joinHousehold(bot *tgbotapi.BotAPI, message *tgbotapi.Message, code string) error {
+	const op = "TelegramBot.joinHousehold"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.lang(message)
+	household, err := b.households.Join(message.From.ID, code)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrHouseholdNotFound):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.HouseholdInvalidCode))
+			return nil
+		case errors.Is(err, services.ErrAlreadyInHousehold):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.HouseholdAlready))
+			return nil
+		}
+		log.Error("join household error", slog.Any("error", err))
+		return err
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.HouseholdJoined, membersText(lang, household)))
+	return nil
+}
+
+// HouseholdCommand управляет семьей: /household - участники и приглашение,
+// /household create, /household leave, /household remove <userId> (для владельца)
+func (b *TelegramBot) HouseholdCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.HouseholdCommand"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.lang(message)
+	action, arg, _ := strings.Cut(strings.TrimSpace(message.CommandArguments()), " ")
+	var err error
+	switch strings.ToLower(action) {
+	case "":
+		var household models.Household
+		if household, err = b.households.Get(message.From.ID); err == nil {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.HouseholdInfo,
+				membersText(lang, household), inviteLink(bot, household)))
+			return nil
+		}
+	case "create":
+		var household models.Household
+		if household, err = b.households.Create(message.From.ID); err == nil {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.HouseholdCreated, inviteLink(bot, household)))
+			return nil
+		}
+	case "leave":
+		var deleted bool
+		if deleted, err = b.households.Leave(message.From.ID); err == nil {
+			if deleted {
+				b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.HouseholdDeleted))
+			} else {
+				b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.HouseholdLeft))
+			}
+			return nil
+		}
+	case "remove":
+		memberId, parseErr := strconv.ParseInt(strings.TrimSpace(arg), 10, 64)
+		if parseErr != nil {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.HouseholdUsage))
+			return nil
+		}
+		var household models.Household
+		if household, err = b.households.Remove(message.From.ID, memberId); err == nil {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.HouseholdRemoved, inviteLink(bot, household)))
+			return nil
+		}
+	default:
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.HouseholdUsage))
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, services.ErrHouseholdNotFound):
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.HouseholdNone))
+		return nil
+	case errors.Is(err, services.ErrAlreadyInHousehold):
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.HouseholdAlready))
+		return nil
+	case errors.Is(err, services.ErrAccessDenied):
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.ActionDenied))
+		return nil
+	case errors.Is(err, services.ErrUserNotFound):
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.UserNotFound))
+		return nil
+	}
+	log.Error("household error", slog.String("action", action), slog.Any("error", err))
+	return err
+}
+
+// AvoidCommand показывает или меняет ограничения в питании:
+// /avoid грибы, свинина; /avoid off - без ограничений.
+// Ужин подбирается с учетом ограничений всех участников семьи.
+func (b *TelegramBot) AvoidCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.AvoidCommand"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.lang(message)
+	args := strings.TrimSpace(message.CommandArguments())
+	if args == "" {
+		own, err := b.households.Restrictions(message.From.ID)
+		if err != nil {
+			log.Error("get restrictions error", slog.Any("error", err))
+			return err
+		}
+		combined, err := b.households.CombinedRestrictions(message.From.ID)
+		if err != nil {
+			log.Error("get restrictions error", slog.Any("error", err))
+			return err
+		}
+		if len(combined) == 0 {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.AvoidNotSet))
+			return nil
+		}
+		lines := make([]string, 0, 2)
+		if len(own) > 0 {
+			lines = append(lines, i18n.T(lang, i18n.AvoidCurrent, strings.Join(own, ", ")))
+		}
+		if len(combined) > len(own) {
+			lines = append(lines, i18n.T(lang, i18n.AvoidHousehold, strings.Join(combined, ", ")))
+		}
+		b.sendText(bot, message.Chat.ID, strings.Join(lines, "\n"))
+		return nil
+	}
+
+	var items []string
+	if !strings.EqualFold(args, "off") {
+		items = splitProducts(args)
+	}
+	if _, err := b.households.SetRestrictions(message.From.ID, items); err != nil {
+		log.Error("set restrictions error", slog.Any("error", err))
+		return err
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.AvoidChanged))
+	return nil
+}
+
+// restrictions отдает ограничения в питании семьи пользователя userId.
+// При ошибке ужин подбирается без ограничений.
+func (b *TelegramBot) restrictions(userId int64) []string {
+	items, err := b.households.CombinedRestrictions(userId)
+	if err != nil {
+		b.log.Error("get restrictions error", slog.Any("error", err))
+	}
+	return items
+}
+
+// inviteLink формирует ссылку-приглашение в семью
+func inviteLink(bot *tgbotapi.BotAPI, household models.Household) string {
+	return "https://t.me/" + bot.Self.UserName + "?start=" + householdservice.JoinPrefix + household.Code
+}
+
+// membersText перечисляет участников семьи, владелец отмечается
+func membersText(lang i18n.Lang, household models.Household) string {
+	names := make([]string, 0, len(household.Members))
+	for _, member := range household.Members {
+		name := strconv.FormatInt(member.ID, 10)
+		if member.UserName != "" {
+			name = "@" + member.UserName
+		}
+		if household.IsOwner(member.ID) {
+			name += " (" + i18n.T(lang, i18n.HouseholdOwner) + ")"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,160 @@
+package telegrambot
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/i18n"
+	"dinner/internal/lib/metrics"
+	"dinner/internal/services"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Сколько вариантов ужина предлагается во встроенном режиме
+const inlineSuggestions = 5
+
+// Сколько секунд варианты ужина хранятся в кэше бота и Telegram:
+// запрос приходит на каждую введенную букву, варианты не должны меняться на глазах
+const inlineCacheTime = 60
+
+// Сколько запросов встроенного режима хранится в кэше
+const inlineCacheSize = 1000
+
+// Запрос встроенного режима
+type inlineKey struct {
+	userID int64
+	query  string
+}
+
+// Варианты ужина по запросу и время, до которого они действительны
+type inlineEntry struct {
+	suggestions [][]models.Food
+	expires     time.Time
+}
+
+// suggestionCache хранит варианты ужина по недавним запросам встроенного режима
+type suggestionCache struct {
+	mu      sync.Mutex
+	entries map[inlineKey]inlineEntry
+}
+
+// put запоминает варианты ужина по запросу key, устаревшие запросы удаляются.
+// Если кэш все равно заполнен, новые варианты не запоминаются.
+func (c *suggestionCache) put(key inlineKey, suggestions [][]models.Food, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[inlineKey]inlineEntry)
+	}
+	if len(c.entries) >= inlineCacheSize {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	if len(c.entries) >= inlineCacheSize {
+		return
+	}
+	c.entries[key] = inlineEntry{suggestions: suggestions, expires: now.Add(inlineCacheTime * time.Second)}
+}
+
+// get отдает варианты ужина по запросу key, если они еще действительны
+func (c *suggestionCache) get(key inlineKey, now time.Time) ([][]models.Food, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expires) {
+		return nil, false
+	}
+	return entry.suggestions, true
+}
+
+// InlineQuery предлагает несколько разных ужинов во встроенном режиме (@бот в любом чате),
+// выбранный вариант отправляется в чат сообщением от пользователя.
+// Текст запроса понимается как аргументы /dinner: "light", "full", "cheap".
+// Учитываются цель по калориям, часовой пояс, ограничения семьи, режим /mode и бюджет.
+// Новые варианты расходуют один запрос из лимита, повторные запросы в течение inlineCacheTime
+// получают те же варианты и лимит не расходуют.
+func (b *TelegramBot) InlineQuery(bot *tgbotapi.BotAPI, query *tgbotapi.InlineQuery) {
+	const op = "TelegramBot.InlineQuery"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.prefs.Language(query.From.ID, query.From.LanguageCode)
+	answer := tgbotapi.InlineConfig{
+		InlineQueryID: query.ID,
+		Results:       []interface{}{},
+		CacheTime:     inlineCacheTime,
+		IsPersonal:    true,
+	}
+	key := inlineKey{userID: query.From.ID, query: strings.ToLower(strings.TrimSpace(query.Query))}
+	now := time.Now()
+	suggestions, ok := b.suggestions.get(key, now)
+	if !ok {
+		opts := b.dinnerOptions(query.From.ID)
+		opts.Light = isLight(key.query)
+		opts.Full = isFullDinner(key.query)
+		opts.Cheap = isCheap(key.query)
+		var err error
+		suggestions, err = b.dinner.GetSuggestions(b.households.Account(query.From.ID), inlineSuggestions, opts)
+		switch {
+		case errors.Is(err, services.ErrAttemptLimitExceeded):
+			metrics.QuotaRejections.Inc()
+			answer.SwitchPMText = i18n.T(lang, i18n.InlineLimit)
+		case errors.Is(err, services.ErrNoMatchingDinner), errors.Is(err, services.ErrEmptyFood):
+			answer.SwitchPMText = i18n.T(lang, i18n.InlineNothing)
+		case err != nil:
+			log.Error("get dinner suggestions error", slog.Any("error", err))
+			metrics.CommandsHandled.WithLabelValues("inline", "error").Inc()
+			return
+		default:
+			b.suggestions.put(key, suggestions, now)
+		}
+		if answer.SwitchPMText != "" {
+			// Кнопка ведет в чат с ботом, где объясняется отказ
+			answer.SwitchPMParameter = "inline"
+		}
+	}
+
+	prices := b.prices()
+	for i, dinner := range suggestions {
+		answer.Results = append(answer.Results, b.inlineResult(lang, strconv.Itoa(i), dinner, prices))
+	}
+	if _, err := bot.Request(answer); err != nil {
+		log.Error("answer inline query error", slog.Any("error", err))
+		metrics.CommandsHandled.WithLabelValues("inline", "error").Inc()
+		return
+	}
+	metrics.CommandsHandled.WithLabelValues("inline", "ok").Inc()
+}
+
+// inlineResult - вариант ужина для встроенного режима:
+// в списке названия блюд, калории и стоимость, в чат отправляется ужин так же, как на /dinner
+func (b *TelegramBot) inlineResult(lang i18n.Lang, id string, foods []models.Food, prices models.Prices) tgbotapi.InlineQueryResultArticle {
+	names := make([]string, 0, len(foods))
+	for _, food := range foods {
+		names = append(names, i18n.FoodName(lang, food))
+	}
+	text := b.formatter.Dinner(lang, foods)
+	result := tgbotapi.NewInlineQueryResultArticle(id, strings.Join(names, ", "), text)
+	if total, ok := models.TotalNutrition(foods); ok {
+		nutrition := i18n.T(lang, i18n.NutritionTotal, total.Kcal, total.Protein, total.Fat, total.Carbs)
+		result.Description = nutrition
+		text += "\n" + nutrition
+	}
+	if cost, ok := prices.DinnerCost(foods); ok {
+		line := i18n.T(lang, i18n.DinnerCost, cost)
+		result.Description = strings.TrimSpace(result.Description + " " + line)
+		text += "\n" + line
+	}
+	result.InputMessageContent = tgbotapi.InputTextMessageContent{
+		Text:      text,
+		ParseMode: b.formatter.ParseMode(),
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package telegrambot
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/i18n"
+	"dinner/internal/services"
+	dinnerservice "dinner/internal/services/dinner"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// KcalCommand показывает или меняет цель по калориям ужина:
+// /kcal 400-700, /kcal -600, /kcal 400-, /kcal off
+func (b *TelegramBot) KcalCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.KcalCommand"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.lang(message)
+	args := strings.TrimSpace(message.CommandArguments())
+	if args == "" {
+		target := b.prefs.KcalTarget(message.From.ID)
+		if target.IsZero() {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.KcalNotSet)+"\n"+i18n.T(lang, i18n.KcalUsage))
+			return nil
+		}
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.KcalCurrent, kcalRangeText(target)))
+		return nil
+	}
+
+	target, err := dinnerservice.ParseKcalRange(args)
+	if err != nil {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.KcalUsage))
+		return err
+	}
+	if err := b.prefs.SetKcalTarget(message.From.ID, target); err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.UserNotFound))
+		}
+		log.Error("set kcal target error", slog.Any("error", err))
+		return err
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.KcalChanged))
+	return nil
+}
+
+// isLight проверяет, что пользователь просит легкий ужин: /dinner light
+func isLight(args string) bool {
+	return hasArg(args, "light", "легкий", "лёгкий")
+}
+
+// hasArg проверяет, что среди аргументов команды есть одно из значений values
+// без учета регистра: /dinner full light
+func hasArg(args string, values ...string) bool {
+	for _, arg := range strings.Fields(strings.ToLower(args)) {
+		if slices.Contains(values, arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// kcalRangeText записывает диапазон калорий: "400-700", "≤ 600" или "≥ 400"
+func kcalRangeText(target models.KcalRange) string {
+	switch {
+	case target.Min == 0:
+		return fmt.Sprintf("≤ %.0f", target.Max)
+	case target.Max == 0:
+		return fmt.Sprintf("≥ %.0f", target.Min)
+	}
+	return fmt.Sprintf("%.0f-%.0f", target.Min, target.Max)
+}
@@ -0,0 +1,50 @@
+package telegrambot
+
+import (
+	"dinner/internal/lib/i18n"
+	"dinner/internal/services"
+	"errors"
+	"log/slog"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// lang отдает язык сообщений для автора сообщения
+func (b *TelegramBot) lang(message *tgbotapi.Message) i18n.Lang {
+	if message.From == nil {
+		return i18n.Default
+	}
+	return b.prefs.Language(message.From.ID, message.From.LanguageCode)
+}
+
+// LangCommand меняет язык сообщений бота: /lang ru|en|auto.
+// Без аргумента показывает текущий язык.
+func (b *TelegramBot) LangCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.LangCommand"
+	log := b.log.With(slog.String("op", op))
+
+	supported := make([]string, 0, len(i18n.Supported))
+	for _, lang := range i18n.Supported {
+		supported = append(supported, string(lang))
+	}
+	available := strings.Join(supported, ", ")
+
+	value := strings.ToLower(strings.TrimSpace(message.CommandArguments()))
+	if value == "" {
+		lang := b.lang(message)
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.LangCurrent, lang, available))
+		return nil
+	}
+
+	if err := b.prefs.SetLanguage(message.From.ID, value); err != nil {
+		if errors.Is(err, services.ErrUnknownLanguage) {
+			b.sendText(bot, message.Chat.ID, i18n.T(b.lang(message), i18n.LangUnknown, available))
+			return nil
+		}
+		log.Error("set language error", slog.Any("error", err))
+		return err
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(b.lang(message), i18n.LangChanged))
+	return nil
+}
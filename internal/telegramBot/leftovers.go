@@ -0,0 +1,101 @@
+package telegrambot
+
+import (
+	"dinner/internal/lib/i18n"
+	"dinner/internal/services"
+	leftoversservice "dinner/internal/services/leftovers"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Формат последнего дня остатков
+const leftoverDateLayout = "02.01"
+
+// LeftoversCommand отмечает последний ужин как остатки или показывает их:
+// /leftovers 2 - доедать еще 2 дня, /leftovers off - остатки закончились.
+// Остатки общие для всей семьи.
+func (b *TelegramBot) LeftoversCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.LeftoversCommand"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.lang(message)
+	now := b.prefs.Now(message.From.ID)
+	args := strings.ToLower(strings.TrimSpace(message.CommandArguments()))
+	switch args {
+	case "":
+		ok, err := b.offerLeftovers(bot, message)
+		if err != nil {
+			log.Error("get leftovers error", slog.Any("error", err))
+			return err
+		}
+		if !ok {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.LeftoversNone))
+		}
+		return nil
+	case "off", "нет":
+		if err := b.leftovers.Clear(b.households.Account(message.From.ID)); err != nil {
+			log.Error("clear leftovers error", slog.Any("error", err))
+			return err
+		}
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.LeftoversCleared))
+		return nil
+	}
+
+	days, err := strconv.Atoi(args)
+	if err != nil || days < 1 || days > leftoversservice.MaxDays {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.LeftoversUsage, leftoversservice.MaxDays))
+		return nil
+	}
+	leftover, err := b.leftovers.Keep(b.households.Account(message.From.ID), days, now)
+	if err != nil {
+		if errors.Is(err, services.ErrNoDinnerToKeep) {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.LeftoversNoDinner))
+			return nil
+		}
+		log.Error("keep leftovers error", slog.Any("error", err))
+		return err
+	}
+	b.sendDinnerText(bot, message.Chat.ID, i18n.T(lang, i18n.LeftoversKept,
+		b.formatter.Dinner(lang, leftover.Foods),
+		leftover.Until.AddDate(0, 0, -1).Format(leftoverDateLayout),
+	))
+	return nil
+}
+
+// offerLeftovers предлагает доесть остатки вместо нового ужина.
+// Отдает true, если остатки есть и сообщение о них отправлено.
+func (b *TelegramBot) offerLeftovers(bot *tgbotapi.BotAPI, message *tgbotapi.Message) (bool, error) {
+	lang := b.lang(message)
+	leftover, ok, err := b.leftovers.Current(b.households.Account(message.From.ID), b.prefs.Now(message.From.ID))
+	if err != nil || !ok {
+		return false, err
+	}
+	b.sendDinnerText(bot, message.Chat.ID, i18n.T(lang, i18n.LeftoversOffer,
+		b.formatter.Dinner(lang, leftover.Foods),
+		leftover.Until.AddDate(0, 0, -1).Format(leftoverDateLayout),
+	))
+	return true, nil
+}
+
+// isNewDinner проверяет, что пользователь просит новый ужин вместо остатков: /dinner new
+func isNewDinner(args string) bool {
+	return hasArg(args, "new", "новый")
+}
+
+// isFullDinner проверяет, что пользователь просит ужин из нескольких подач: /dinner full
+func isFullDinner(args string) bool {
+	return hasArg(args, "full", "полный")
+}
+
+// sendDinnerText отправляет текст с разметкой форматтера ужинов в чат chatID
+func (b *TelegramBot) sendDinnerText(bot *tgbotapi.BotAPI, chatID int64, text string) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = b.formatter.ParseMode()
+	if _, err := b.send(bot, msg); err != nil {
+		b.log.Error("send message error", slog.Attr{Key: "error", Value: slog.StringValue(err.Error())})
+	}
+}
@@ -0,0 +1,140 @@
+package telegrambot
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/i18n"
+	"dinner/internal/services"
+	dinnerservice "dinner/internal/services/dinner"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// ModeCommand показывает или меняет стратегию выбора ужина:
+// /mode random, /mode rating, /mode fresh, /mode roundrobin, /mode shuffle, /mode auto
+func (b *TelegramBot) ModeCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.ModeCommand"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.lang(message)
+	value := strings.ToLower(strings.TrimSpace(message.CommandArguments()))
+	if value == "" {
+		b.sendText(bot, message.Chat.ID, modesText(lang, b.prefs.Mode(message.From.ID)))
+		return nil
+	}
+	if err := b.prefs.SetMode(message.From.ID, value); err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnknownMode):
+			b.sendText(bot, message.Chat.ID, modesText(lang, b.prefs.Mode(message.From.ID)))
+			return nil
+		case errors.Is(err, services.ErrUserNotFound):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.UserNotFound))
+		}
+		log.Error("set mode error", slog.Any("error", err))
+		return err
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.ModeChanged, modeName(lang, b.prefs.Mode(message.From.ID))))
+	return nil
+}
+
+// modesText описывает текущую стратегию и перечисляет все доступные
+func modesText(lang i18n.Lang, current string) string {
+	lines := []string{i18n.T(lang, i18n.ModeCurrent, modeName(lang, current))}
+	for _, name := range dinnerservice.StrategyNames() {
+		lines = append(lines, fmt.Sprintf("/mode %s - %s", name, modeName(lang, name)))
+	}
+	lines = append(lines, i18n.T(lang, i18n.ModeUsage))
+	return strings.Join(lines, "\n")
+}
+
+// modeName отдает описание стратегии на языке lang
+func modeName(lang i18n.Lang, mode string) string {
+	if mode == "" {
+		mode = dinnerservice.StrategyRandom
+	}
+	return i18n.T(lang, i18n.Key("mode_"+mode))
+}
+
+// RateCommand ставит оценку блюду: /rate Борщ 5, /rate Борщ 0 - убрать оценку.
+// Без аргументов показывает оценки. Оценки учитываются в режиме /mode rating,
+// участники семьи делят оценки.
+func (b *TelegramBot) RateCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.RateCommand"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.lang(message)
+	account := b.households.Account(message.From.ID)
+	args := strings.TrimSpace(message.CommandArguments())
+	if args == "" {
+		text, err := b.ratingsText(lang, account)
+		if err != nil {
+			log.Error("get ratings error", slog.Any("error", err))
+			return err
+		}
+		b.sendText(bot, message.Chat.ID, text)
+		return nil
+	}
+
+	i := strings.LastIndex(args, " ")
+	if i < 0 {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.RateUsage))
+		return nil
+	}
+	rating, err := strconv.Atoi(args[i+1:])
+	if err != nil {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.RateUsage))
+		return nil
+	}
+	food, err := b.catalog.FoodByName(args[:i])
+	if err == nil {
+		err = b.dinner.Rate(account, food.ID, rating)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrFoodNotFound):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.RateUnknownFood, strings.TrimSpace(args[:i])))
+			return nil
+		case errors.Is(err, services.ErrInvalidRating):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.RateUsage))
+			return nil
+		}
+		log.Error("rate food error", slog.Any("error", err))
+		return err
+	}
+	if rating == 0 {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.RateCleared, i18n.FoodName(lang, food)))
+		return nil
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.RateSaved, i18n.FoodName(lang, food), rating))
+	return nil
+}
+
+// ratingsText перечисляет оценки блюд пользователя userId, лучшие первыми
+func (b *TelegramBot) ratingsText(lang i18n.Lang, userId int64) (string, error) {
+	ratings, err := b.dinner.Ratings(userId)
+	if err != nil {
+		return "", err
+	}
+	if len(ratings) == 0 {
+		return i18n.T(lang, i18n.RateNone) + "\n" + i18n.T(lang, i18n.RateUsage), nil
+	}
+	foods, err := b.catalog.Foods()
+	if err != nil {
+		return "", err
+	}
+	slices.SortStableFunc(foods, func(a, c models.Food) int {
+		return ratings[c.ID] - ratings[a.ID]
+	})
+	lines := []string{i18n.T(lang, i18n.RateList)}
+	for _, food := range foods {
+		if rating, ok := ratings[food.ID]; ok {
+			lines = append(lines, fmt.Sprintf("%s - %s", i18n.FoodName(lang, food), strings.Repeat("★", rating)))
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,127 @@
+package telegrambot
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/i18n"
+	"dinner/internal/services"
+	adminservice "dinner/internal/services/admin"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Разделитель аргументов /pair: названия блюд могут содержать пробелы
+const pairSeparator = ";"
+
+// PairCommand показывает или меняет сочетания мяса и гарниров (для администраторов).
+// /pair - таблица сочетаний, /pair Жульен; Макароны; prefer - вес сочетания.
+func (b *TelegramBot) PairCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.PairCommand"
+	log := b.log.With(slog.String("op", op))
+
+	if !b.admin.IsAdmin(message.From.ID) {
+		return services.ErrAccessDenied
+	}
+	lang := b.lang(message)
+	args := strings.TrimSpace(message.CommandArguments())
+	if args == "" {
+		text, err := b.pairingsText(lang)
+		if err != nil {
+			log.Error("get pairings error", slog.Any("error", err))
+			return err
+		}
+		b.sendText(bot, message.Chat.ID, text)
+		return nil
+	}
+
+	parts := strings.Split(args, pairSeparator)
+	if len(parts) != 3 {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PairUsage))
+		return nil
+	}
+	weight, ok := models.ParsePairWeight(parts[2])
+	if !ok {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PairUsage))
+		return nil
+	}
+	if err := b.admin.Audit(message.From.ID, adminservice.ActionPairing, args); err != nil {
+		return err
+	}
+
+	pairing, err := b.setPairing(parts[0], parts[1], weight)
+	if err != nil {
+		if errors.Is(err, services.ErrFoodNotFound) || errors.Is(err, services.ErrInvalidPairing) {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PairInvalid, args))
+			return nil
+		}
+		log.Error("set pairing error", slog.Any("error", err))
+		return err
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PairSaved, pairing))
+	return nil
+}
+
+// setPairing находит блюда по названию и сохраняет вес их сочетания.
+// Отдает описание сохраненного сочетания.
+func (b *TelegramBot) setPairing(first, second string, weight int) (string, error) {
+	a, err := b.catalog.FoodByName(first)
+	if err != nil {
+		return "", err
+	}
+	c, err := b.catalog.FoodByName(second)
+	if err != nil {
+		return "", err
+	}
+	pairing, err := b.catalog.SetPairing(models.Pairing{MeatID: a.ID, SideID: c.ID, Weight: weight})
+	if err != nil {
+		return "", err
+	}
+	if pairing.MeatID != a.ID {
+		a, c = c, a
+	}
+	return pairingLine(a.Name, c.Name, pairing.Weight), nil
+}
+
+// pairingsText формирует таблицу сочетаний с необычным весом
+func (b *TelegramBot) pairingsText(lang i18n.Lang) (string, error) {
+	pairings, err := b.catalog.Pairings()
+	if err != nil {
+		return "", err
+	}
+	if len(pairings) == 0 {
+		return i18n.T(lang, i18n.PairEmpty) + "\n" + i18n.T(lang, i18n.PairUsage), nil
+	}
+	foods, err := b.catalog.Foods()
+	if err != nil {
+		return "", err
+	}
+	names := make(map[int64]string, len(foods))
+	for _, food := range foods {
+		names[food.ID] = food.Name
+	}
+	lines := make([]string, 0, len(pairings))
+	for _, p := range pairings {
+		lines = append(lines, pairingLine(names[p.MeatID], names[p.SideID], p.Weight))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// pairingLine записывает сочетание: "Жульен + Макароны: prefer (3)"
+func pairingLine(meat, side string, weight int) string {
+	label := ""
+	switch weight {
+	case models.PairForbidden:
+		label = "forbid"
+	case models.PairAllowed:
+		label = "allow"
+	case models.PairPreferred:
+		label = "prefer"
+	}
+	if label == "" {
+		return fmt.Sprintf("%s + %s: %d", meat, side, weight)
+	}
+	return fmt.Sprintf("%s + %s: %s (%d)", meat, side, label, weight)
+}
@@ -0,0 +1,132 @@
+package telegrambot
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/i18n"
+	"dinner/internal/lib/metrics"
+	"dinner/internal/services"
+	adminservice "dinner/internal/services/admin"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Аргумент /photo, удаляющий фото блюда
+const photoOff = "off"
+
+// Наибольшая длина подписи к фото в Telegram
+const captionLimit = 1024
+
+// Наибольшее количество фото в альбоме Telegram
+const mediaGroupLimit = 10
+
+// PhotoCommand сохраняет фото блюда (для администраторов): фото с подписью "/photo Борщ".
+// Сохраняется file_id самого большого размера фото, сам файл остается в Telegram.
+// "/photo Борщ off" удаляет фото, блюдо снова предлагается текстом.
+func (b *TelegramBot) PhotoCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.PhotoCommand"
+	log := b.log.With(slog.String("op", op))
+
+	if !b.admin.IsAdmin(message.From.ID) {
+		return services.ErrAccessDenied
+	}
+	lang := b.lang(message)
+	args := message.CommandArguments()
+	if len(message.Photo) > 0 {
+		args = strings.Join(strings.Fields(message.Caption)[1:], " ")
+	}
+	name, remove := strings.TrimSpace(args), false
+	if rest, ok := strings.CutSuffix(name, " "+photoOff); ok && len(message.Photo) == 0 {
+		name, remove = strings.TrimSpace(rest), true
+	}
+	if name == "" || (len(message.Photo) == 0 && !remove) {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PhotoUsage))
+		return nil
+	}
+	if err := b.admin.Audit(message.From.ID, adminservice.ActionFoodPhoto, args); err != nil {
+		return err
+	}
+
+	photo := ""
+	if !remove {
+		// Размеры фото идут по возрастанию
+		photo = message.Photo[len(message.Photo)-1].FileID
+	}
+	food, err := b.catalog.FoodByName(name)
+	if err == nil {
+		err = b.catalog.SetPhoto(food.ID, photo)
+	}
+	if err != nil {
+		if errors.Is(err, services.ErrFoodNotFound) {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.RateUnknownFood, name))
+			return nil
+		}
+		log.Error("set food photo error", slog.Any("error", err))
+		return err
+	}
+	if remove {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PhotoRemoved, i18n.FoodName(lang, food)))
+		return nil
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PhotoSaved, i18n.FoodName(lang, food)))
+	return nil
+}
+
+// dinnerPhotos отдает фото блюд ужина в порядке блюд
+func dinnerPhotos(foods []models.Food) []string {
+	photos := []string{}
+	for _, food := range foods {
+		if food.Photo != "" {
+			photos = append(photos, food.Photo)
+		}
+	}
+	return photos
+}
+
+// sendWithPhotos отправляет текст ужина text с кнопками keyboard в чат chatID вместе с фото блюд.
+// Одно фото отправляется с подписью text и кнопками, несколько - альбомом,
+// за которым следует text с кнопками: у альбома кнопок не бывает.
+// Если text не помещается в подпись, одно фото отправляется без подписи, а за ним - text с кнопками.
+// Если фото отправить не удалось (например, file_id от другого бота), ужин отправляется текстом.
+// Отдает сообщение с кнопками.
+func (b *TelegramBot) sendWithPhotos(bot *tgbotapi.BotAPI, chatID int64, text string, photos []string, keyboard tgbotapi.InlineKeyboardMarkup) (tgbotapi.Message, error) {
+	const op = "TelegramBot.sendWithPhotos"
+	log := b.log.With(slog.String("op", op))
+
+	switch {
+	case len(photos) == 1 && utf8.RuneCountInString(text) <= captionLimit:
+		msg := tgbotapi.NewPhoto(chatID, tgbotapi.FileID(photos[0]))
+		msg.Caption = text
+		msg.ParseMode = b.formatter.ParseMode()
+		msg.ReplyMarkup = keyboard
+		sent, err := b.send(bot, msg)
+		if err == nil {
+			return sent, nil
+		}
+		log.Warn("send photo error, sending text", slog.Any("error", err))
+	case len(photos) == 1:
+		if _, err := b.send(bot, tgbotapi.NewPhoto(chatID, tgbotapi.FileID(photos[0]))); err != nil {
+			log.Warn("send photo error, sending text", slog.Any("error", err))
+		}
+	case len(photos) > 1:
+		media := make([]interface{}, 0, len(photos))
+		for _, photo := range photos[:min(len(photos), mediaGroupLimit)] {
+			media = append(media, tgbotapi.NewInputMediaPhoto(tgbotapi.FileID(photo)))
+		}
+		start := time.Now()
+		_, err := bot.SendMediaGroup(tgbotapi.NewMediaGroup(chatID, media))
+		metrics.TelegramSendDuration.Observe(time.Since(start).Seconds())
+		if err != nil {
+			log.Warn("send media group error, sending text", slog.Any("error", err))
+		}
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = b.formatter.ParseMode()
+	msg.ReplyMarkup = keyboard
+	return b.send(bot, msg)
+}
@@ -0,0 +1,99 @@
+package telegrambot
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/formatter"
+	"dinner/internal/lib/i18n"
+	"dinner/internal/lib/metrics"
+	"dinner/internal/services"
+	dinnerservice "dinner/internal/services/dinner"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// PrepCommand подбирает ужины для заготовки на неделю с общими ингредиентами:
+// /prep 3 4 - 3 ужина по 4 порции, без аргументов - dinnerservice.DefaultPrepDinners по DefaultPrepPortions.
+// Отвечает порядком готовки и списком покупок.
+func (b *TelegramBot) PrepCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.PrepCommand"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.lang(message)
+	dinners, portions, ok := parsePrepArgs(message.CommandArguments())
+	if !ok {
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PrepUsage, dinnerservice.MaxPrepDinners, dinnerservice.MaxPrepPortions))
+		return nil
+	}
+	prep, err := b.dinner.GetMealPrep(b.households.Account(message.From.ID), dinners, portions, b.dinnerOptions(message.From.ID))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidMealPrep):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.PrepUsage, dinnerservice.MaxPrepDinners, dinnerservice.MaxPrepPortions))
+			return nil
+		case errors.Is(err, services.ErrNoMatchingDinner):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.NoMatchingDinner))
+			return err
+		case errors.Is(err, services.ErrAttemptLimitExceeded):
+			metrics.QuotaRejections.Inc()
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.LimitExceeded))
+			return err
+		}
+		log.Error("get meal prep error", slog.Any("error", err))
+		return err
+	}
+	b.sendText(bot, message.Chat.ID, prepText(lang, prep))
+	return nil
+}
+
+// parsePrepArgs разбирает аргументы /prep: количество ужинов и порций
+func parsePrepArgs(args string) (dinners int, portions int, ok bool) {
+	dinners, portions = dinnerservice.DefaultPrepDinners, dinnerservice.DefaultPrepPortions
+	fields := strings.Fields(args)
+	if len(fields) > 2 {
+		return 0, 0, false
+	}
+	var err error
+	if len(fields) > 0 {
+		if dinners, err = strconv.Atoi(fields[0]); err != nil {
+			return 0, 0, false
+		}
+	}
+	if len(fields) > 1 {
+		if portions, err = strconv.Atoi(fields[1]); err != nil {
+			return 0, 0, false
+		}
+	}
+	return dinners, portions, true
+}
+
+// prepText формирует текст заготовки на языке lang: ужины, порядок готовки и список покупок
+func prepText(lang i18n.Lang, prep models.MealPrep) string {
+	plain := formatter.New(formatter.Plain)
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, i18n.PrepTitle, len(prep.Dinners), prep.Portions) + "\n")
+	for i, dinner := range prep.Dinners {
+		fmt.Fprintf(&sb, "%d. %s\n", i+1, plain.Dinner(lang, dinner))
+	}
+	sb.WriteString("\n" + i18n.T(lang, i18n.PrepCookOrder) + "\n")
+	for i, food := range prep.CookOrder {
+		fmt.Fprintf(&sb, "%d. %s\n", i+1, i18n.FoodName(lang, food))
+	}
+	sb.WriteString("\n")
+	if len(prep.Shopping) == 0 {
+		sb.WriteString(i18n.T(lang, i18n.PrepNoIngredients))
+		return sb.String()
+	}
+	sb.WriteString(i18n.T(lang, i18n.PrepShopping) + "\n")
+	for _, item := range prep.Shopping {
+		sb.WriteString("- " + i18n.T(lang, i18n.PrepItem, item.Ingredient, item.Dishes, item.Portions) + "\n")
+	}
+	if shared := prep.Shared(); len(shared) > 0 {
+		sb.WriteString("\n" + i18n.T(lang, i18n.PrepShared, strings.Join(shared, ", ")))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,75 @@
+package telegrambot
+
+import (
+	"bytes"
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/i18n"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// StatsCommand отправляет пользователю его личную статистику.
+// Месяц и серии дней считаются в часовом поясе пользователя (/tz).
+func (b *TelegramBot) StatsCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.StatsCommand"
+	log := b.log.With(slog.String("op", op))
+
+	stats, err := b.stats.GetStats(b.households.Account(message.From.ID), b.prefs.Now(message.From.ID))
+	if err != nil {
+		log.Error("get stats error", slog.Any("error", err))
+		return err
+	}
+	b.sendText(bot, message.Chat.ID, statsText(b.lang(message), stats))
+	return nil
+}
+
+// statsText формирует текст сообщения со статистикой на языке lang
+func statsText(lang i18n.Lang, stats models.UserStats) string {
+	if stats.TotalRequests == 0 {
+		return i18n.T(lang, i18n.EmptyHistory)
+	}
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, i18n.StatsRequests, stats.MonthRequests, stats.TotalRequests) + "\n")
+	sb.WriteString(i18n.T(lang, i18n.StatsStreak, stats.CurrentStreak, stats.LongestStreak) + "\n")
+	if len(stats.TopFoods) > 0 {
+		sb.WriteString("\n" + i18n.T(lang, i18n.StatsTop) + "\n")
+		for _, count := range stats.TopFoods {
+			fmt.Fprintf(&sb, "- %s: %d\n", i18n.FoodName(lang, count.Food), count.Count)
+		}
+		sb.WriteString("\n" + i18n.T(lang, i18n.StatsBottom) + "\n")
+		for _, count := range stats.BottomFoods {
+			fmt.Fprintf(&sb, "- %s: %d\n", i18n.FoodName(lang, count.Food), count.Count)
+		}
+	}
+	if len(stats.Categories) > 0 {
+		sb.WriteString("\n" + i18n.T(lang, i18n.StatsCategories) + "\n")
+		for _, count := range stats.Categories {
+			fmt.Fprintf(&sb, "- %s: %d\n", i18n.CategoryName(lang, count.Category), count.Count)
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// ExportHistoryCommand отправляет пользователю его историю файлом CSV
+func (b *TelegramBot) ExportHistoryCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.ExportHistoryCommand"
+	log := b.log.With(slog.String("op", op))
+
+	var buf bytes.Buffer
+	if err := b.stats.ExportHistory(b.households.Account(message.From.ID), &buf); err != nil {
+		log.Error("export history error", slog.Any("error", err))
+		return err
+	}
+	doc := tgbotapi.NewDocument(message.Chat.ID, tgbotapi.FileBytes{
+		Name:  "history.csv",
+		Bytes: buf.Bytes(),
+	})
+	if _, err := b.send(bot, doc); err != nil {
+		log.Error("send document error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
@@ -1,11 +1,23 @@
 package telegrambot
 
 import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/formatter"
+	"dinner/internal/lib/i18n"
+	"dinner/internal/lib/metrics"
 	"dinner/internal/services"
+	adminservice "dinner/internal/services/admin"
+	catalogservice "dinner/internal/services/catalog"
 	dinnerservice "dinner/internal/services/dinner"
+	householdservice "dinner/internal/services/household"
+	leftoversservice "dinner/internal/services/leftovers"
+	preferencesservice "dinner/internal/services/preferences"
+	statsservice "dinner/internal/services/stats"
 	"errors"
 	"log/slog"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -15,6 +27,23 @@ type TelegramBot struct {
 	token   string
 	timeout int
 	dinner  *dinnerservice.Dinner
+	catalog *catalogservice.Catalog
+	stats   *statsservice.Stats
+	admin   *adminservice.Admin
+	// Настройки пользователей: язык, калории, бюджет, часовой пояс, режим
+	prefs *preferencesservice.Preferences
+	// Остатки ужинов, которые доедают несколько дней
+	leftovers *leftoversservice.Leftovers
+	// Семьи с общей историей и ограничениями в питании
+	households *householdservice.Households
+	// Объяснения подбора последних ужинов для кнопки "Почему?"
+	traces traceCache
+	// Варианты ужина по недавним запросам встроенного режима
+	suggestions suggestionCache
+	// Форматирование сообщений с составом ужина
+	formatter *formatter.Formatter
+	// Установлено ли подключение к Telegram
+	ready atomic.Bool
 }
 
 // New Конструктор бота
@@ -22,16 +51,105 @@ type TelegramBot struct {
 // token string - токен бота (берется из переменной окружения)
 // timeout int - таймаут
 // dinner *dinnerservice.Dinner - сервис, который генерит что приготовить на ужин
-func New(log *slog.Logger, token string, timeout int, dinner *dinnerservice.Dinner) *TelegramBot {
+// catalog *catalogservice.Catalog - сервис импорта и экспорта каталога блюд
+// stats *statsservice.Stats - сервис личной статистики
+// admin *adminservice.Admin - сервис пользователей и команд администраторов
+// prefs *preferencesservice.Preferences - сервис настроек пользователей
+// leftovers *leftoversservice.Leftovers - сервис остатков ужинов
+// households *householdservice.Households - сервис семей
+func New(
+	log *slog.Logger,
+	token string,
+	timeout int,
+	dinner *dinnerservice.Dinner,
+	catalog *catalogservice.Catalog,
+	stats *statsservice.Stats,
+	admin *adminservice.Admin,
+	prefs *preferencesservice.Preferences,
+	leftovers *leftoversservice.Leftovers,
+	households *householdservice.Households,
+) *TelegramBot {
 	// TODO: проверка валидности токена
 	return &TelegramBot{
-		log:     log,
-		token:   token,
-		timeout: timeout,
-		dinner:  dinner,
+		log:        log,
+		token:      token,
+		timeout:    timeout,
+		dinner:     dinner,
+		catalog:    catalog,
+		stats:      stats,
+		admin:      admin,
+		prefs:      prefs,
+		leftovers:  leftovers,
+		households: households,
+		formatter:  formatter.New(formatter.HTML),
 	}
 }
 
+// Обработчик команды бота
+type commandHandler func(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error
+
+// handlers отдает обработчики команд по их названию
+func (b *TelegramBot) handlers() map[string]commandHandler {
+	return map[string]commandHandler{
+		// Приветствие и вход в семью по ссылке-приглашению
+		"start": b.StartCommand,
+		// Что приготовить на ужин
+		"dinner": b.DinnerCommand,
+		// Остатки последнего ужина на несколько дней
+		"leftovers": b.LeftoversCommand,
+		// Семья: общая история, лимит и остатки
+		"household": b.HouseholdCommand,
+		// Ограничения в питании
+		"avoid": b.AvoidCommand,
+		// Поиск блюда в каталоге и ужин вокруг него
+		"find": b.FindCommand,
+		// Стратегия выбора ужина
+		"mode": b.ModeCommand,
+		// Оценки блюд
+		"rate": b.RateCommand,
+		// Цель по калориям ужина
+		"kcal": b.KcalCommand,
+		// Бюджет на ужины за неделю
+		"budget": b.BudgetCommand,
+		// Часовой пояс для учета сезона и дня недели
+		"tz": b.TzCommand,
+		// Что приготовить из имеющихся продуктов
+		"cook": b.CookCommand,
+		// Заготовка ужинов на неделю
+		"prep": b.PrepCommand,
+		// Личная статистика
+		"stats": b.StatsCommand,
+		// Язык сообщений
+		"lang": b.LangCommand,
+		// История запросов файлом
+		"export": b.ExportHistoryCommand,
+		// Каталог блюд файлом (для администраторов)
+		"catalog": b.CatalogCommand,
+		// Загрузка каталога блюд из файла (для администраторов)
+		"import": b.ImportCommand,
+		// Сочетания мяса и гарниров (для администраторов)
+		"pair": b.PairCommand,
+		// Фото блюда (для администраторов)
+		"photo": b.PhotoCommand,
+		// Цены блюд и ингредиентов (для администраторов)
+		"price": b.PriceCommand,
+		// Количество пользователей (для администраторов)
+		"users": b.UsersCommand,
+		// Рассылка всем пользователям (для администраторов)
+		"broadcast": b.BroadcastCommand,
+		// Сброс лимита запросов пользователя (для администраторов)
+		"resetlimit": b.ResetLimitCommand,
+		// Блокировка и разблокировка пользователя (для администраторов)
+		"ban":   b.BanCommand,
+		"unban": b.UnbanCommand,
+	}
+}
+
+// Ready сообщает, установлено ли подключение к Telegram
+func (b *TelegramBot) Ready() bool {
+	return b.ready.Load()
+}
+
 // Run основной поток бота
 func (b *TelegramBot) Run() {
 	const op = "TelegramBot.Run"
@@ -42,42 +160,130 @@ func (b *TelegramBot) Run() {
 	}
 
 	bot.Debug = false
+	b.ready.Store(true)
 
+	handlers := b.handlers()
 	updateConfig := tgbotapi.NewUpdate(0)
 	updateConfig.Timeout = b.timeout
 	updates := bot.GetUpdatesChan(updateConfig)
 	for update := range updates {
-
-		if update.Message == nil {
+		// Нажатие кнопки под сообщением
+		if update.CallbackQuery != nil && update.CallbackQuery.From != nil {
+			if banned, err := b.admin.IsBanned(update.CallbackQuery.From.ID); err != nil || banned {
+				continue
+			}
+			b.CallbackQuery(bot, update.CallbackQuery)
 			continue
 		}
-		// Обработка команды /dinner
-		if update.Message.Text == "/dinner" {
-			err := b.DinnerCommand(bot, update.Message)
-			if err != nil {
+		// Встроенный режим: @бот в любом чате
+		if update.InlineQuery != nil && update.InlineQuery.From != nil {
+			if banned, err := b.admin.IsBanned(update.InlineQuery.From.ID); err != nil || banned {
 				continue
 			}
-			log.Info("apply command '/dinner'")
+			b.InlineQuery(bot, update.InlineQuery)
+			continue
 		}
+
+		if update.Message == nil || update.Message.From == nil {
+			continue
+		}
+		// Запоминаем пользователя, заблокированных игнорируем
+		banned, err := b.admin.Register(models.User{
+			ID:       update.Message.From.ID,
+			ChatID:   update.Message.Chat.ID,
+			UserName: update.Message.From.UserName,
+			LastSeen: time.Now(),
+		})
+		if err != nil {
+			log.Error("register user error", slog.Any("error", err))
+		}
+		if banned {
+			continue
+		}
+		command := messageCommand(update.Message)
+		handler, ok := handlers[command]
+		if !ok {
+			continue
+		}
+		err = handler(bot, update.Message)
+		if err != nil {
+			metrics.CommandsHandled.WithLabelValues(command, "error").Inc()
+			continue
+		}
+		metrics.CommandsHandled.WithLabelValues(command, "ok").Inc()
+		log.Info("apply command '/" + command + "'")
 	}
 }
 
+// messageCommand отдает название команды из текста сообщения
+// или из подписи к файлу или фото (например, "/import" к файлу каталога)
+func messageCommand(message *tgbotapi.Message) string {
+	if message.IsCommand() {
+		return message.Command()
+	}
+	if message.Document != nil || len(message.Photo) > 0 {
+		args := strings.Fields(message.Caption)
+		if len(args) > 0 && strings.HasPrefix(args[0], "/") {
+			return strings.TrimPrefix(args[0], "/")
+		}
+	}
+	return ""
+}
+
+// send отправляет сообщение в Telegram и учитывает время отправки в метриках
+func (b *TelegramBot) send(bot *tgbotapi.BotAPI, c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	start := time.Now()
+	msg, err := bot.Send(c)
+	metrics.TelegramSendDuration.Observe(time.Since(start).Seconds())
+	return msg, err
+}
+
 // DinnerCommand запрашивет у сервиса блюда на ужин.
+// С аргументом "light" подбирается легкий ужин, цель по калориям из /kcal учитывается всегда.
+// С аргументом "full" подбирается ужин из нескольких подач: закуска, суп и основное блюдо.
+// С аргументом "cheap" - ужин не дороже бюджета одного ужина (/budget), без бюджета -
+// из более дешевой половины ужинов. Примерная стоимость показывается, если известна.
+// Сезон и день недели определяются по дате в часовом поясе пользователя (/tz).
+// Пока есть остатки (/leftovers), предлагаются они и лимит запросов не расходуется,
+// "/dinner new" подбирает новый ужин.
+// Участники семьи (/household) делят историю, лимит и остатки, ограничения в питании
+// (/avoid) всех участников учитываются. Блюдо выбирается стратегией из /mode.
 func (b *TelegramBot) DinnerCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
-	if message.Text != "/dinner" {
+	if message.Command() != "dinner" {
 		return nil
 	}
 	const op = "TelegramBot.DinnerCommand"
 	log := b.log.With(slog.String("op", op))
-	// Получение блюд
-	foods, err := b.dinner.GetRandomDinner(message.From.ID)
+	if !isNewDinner(message.CommandArguments()) {
+		ok, err := b.offerLeftovers(bot, message)
+		if err != nil {
+			log.Error("get leftovers error", slog.Any("error", err))
+		}
+		if ok {
+			return nil
+		}
+	}
+	// Получение блюд, зерно пишем в лог, чтобы подбор можно было повторить
+	seed := b.dinner.NextSeed()
+	log = log.With(slog.Uint64("seed", seed))
+	opts := b.dinnerOptions(message.From.ID)
+	opts.Light = isLight(message.CommandArguments())
+	opts.Full = isFullDinner(message.CommandArguments())
+	opts.Cheap = isCheap(message.CommandArguments())
+	foods, trace, err := b.dinner.WithSeed(seed).GetDinnerTrace(b.households.Account(message.From.ID), opts)
 	if err != nil {
+		// Нет ужина под цель по калориям
+		if errors.Is(err, services.ErrNoMatchingDinner) {
+			b.sendText(bot, message.Chat.ID, i18n.T(b.lang(message), i18n.NoMatchingDinner))
+			return err
+		}
 		// Превышен лимит запросов
 		if errors.Is(err, services.ErrAttemptLimitExceeded) {
+			metrics.QuotaRejections.Inc()
 			b.log.Debug("user attempt limit exceeded", slog.Attr{Key: "error", Value: slog.StringValue(err.Error())})
 
-			msg := tgbotapi.NewMessage(message.Chat.ID, "Лимит попыток исчерпан")
-			if _, err := bot.Send(msg); err != nil {
+			msg := tgbotapi.NewMessage(message.Chat.ID, i18n.T(b.lang(message), i18n.LimitExceeded))
+			if _, err := b.send(bot, msg); err != nil {
 				log.Error("send message error", slog.Attr{Key: "error", Value: slog.StringValue(err.Error())})
 			}
 		}
@@ -90,16 +296,43 @@ func (b *TelegramBot) DinnerCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Mess
 		log.Error("get random dinner error", slog.Any("error", slog.Attr{Key: "error", Value: slog.StringValue(services.ErrEmptyFood.Error())}))
 		return services.ErrEmptyFood
 	}
-	// Формирование ответного сообщения
-	msgFood := foods[0].Name
-	for i := 1; i < len(foods); i++ {
-		runs := []rune(foods[i].Name)
-		msgFood = msgFood + " и " + strings.ToLower(string(runs[0:1])) + string(runs[1:])
+	b.sendDinner(bot, message.Chat.ID, b.lang(message), foods, opts.Prices, trace)
+	return nil
+}
+
+// dinnerOptions отдает условия подбора ужина для пользователя userId:
+// цель по калориям, дату в его часовом поясе, ограничения в питании семьи,
+// режим подбора, бюджет семьи (см. BudgetCommand) и цены ингредиентов
+func (b *TelegramBot) dinnerOptions(userId int64) dinnerservice.Options {
+	return dinnerservice.Options{
+		Kcal:    b.prefs.KcalTarget(userId),
+		Date:    b.prefs.Now(userId),
+		Exclude: b.restrictions(userId),
+		Mode:    b.prefs.Mode(userId),
+		Budget:  b.prefs.Budget(b.households.Account(userId)),
+		Prices:  b.prices(),
+	}
+}
+
+// sendDinner отправляет ужин в чат chatID на языке lang с пищевой ценностью,
+// примерной стоимостью по ценам prices, фото блюд (см. sendWithPhotos) и кнопкой "Почему?",
+// объяснение подбора trace запоминается для кнопки
+func (b *TelegramBot) sendDinner(bot *tgbotapi.BotAPI, chatID int64, lang i18n.Lang, foods []models.Food, prices models.Prices, trace models.Trace) {
+	const op = "TelegramBot.sendDinner"
+
+	metrics.DinnersGenerated.WithLabelValues(metrics.Composition(foods)).Inc()
+	// Формирование ответного сообщения на языке пользователя
+	msgFood := b.formatter.Dinner(lang, foods)
+	if total, ok := models.TotalNutrition(foods); ok {
+		msgFood += "\n" + i18n.T(lang, i18n.NutritionTotal, total.Kcal, total.Protein, total.Fat, total.Carbs)
 	}
-	// Отправка сообщения пользователю
-	msg := tgbotapi.NewMessage(message.Chat.ID, msgFood)
-	if _, err := bot.Send(msg); err != nil {
-		log.Error("send message error", slog.Attr{Key: "error", Value: slog.StringValue(err.Error())})
+	if cost, ok := prices.DinnerCost(foods); ok {
+		msgFood += "\n" + i18n.T(lang, i18n.DinnerCost, cost)
 	}
-	return nil
+	sent, err := b.sendWithPhotos(bot, chatID, msgFood, dinnerPhotos(foods), whyKeyboard(lang))
+	if err != nil {
+		b.log.Error("send message error", slog.String("op", op), slog.Any("error", err))
+		return
+	}
+	b.traces.put(messageKey{chatID: chatID, messageID: sent.MessageID}, trace)
 }
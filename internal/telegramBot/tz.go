@@ -0,0 +1,55 @@
+package telegrambot
+
+import (
+	"dinner/internal/lib/i18n"
+	"dinner/internal/services"
+	"errors"
+	"log/slog"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Формат текущего времени в ответе /tz
+const tzTimeLayout = "02.01 15:04"
+
+// TzCommand показывает или меняет часовой пояс пользователя:
+// /tz Europe/Moscow, /tz auto
+func (b *TelegramBot) TzCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) error {
+	const op = "TelegramBot.TzCommand"
+	log := b.log.With(slog.String("op", op))
+
+	lang := b.lang(message)
+	value := strings.TrimSpace(message.CommandArguments())
+	if value == "" {
+		timezone, err := b.prefs.Timezone(message.From.ID)
+		if err != nil {
+			log.Error("get timezone error", slog.Any("error", err))
+			return err
+		}
+		now := b.prefs.Now(message.From.ID).Format(tzTimeLayout)
+		if timezone == "" {
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.TzNotSet, now)+"\n"+i18n.T(lang, i18n.TzUsage))
+			return nil
+		}
+		b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.TzCurrent, timezone, now))
+		return nil
+	}
+
+	if strings.EqualFold(value, "auto") {
+		value = "auto"
+	}
+	if err := b.prefs.SetTimezone(message.From.ID, value); err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnknownTimezone):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.TzUsage))
+			return nil
+		case errors.Is(err, services.ErrUserNotFound):
+			b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.UserNotFound))
+		}
+		log.Error("set timezone error", slog.Any("error", err))
+		return err
+	}
+	b.sendText(bot, message.Chat.ID, i18n.T(lang, i18n.TzChanged)+", "+b.prefs.Now(message.From.ID).Format(tzTimeLayout))
+	return nil
+}
@@ -0,0 +1,143 @@
+package telegrambot
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/i18n"
+	"dinner/internal/lib/metrics"
+	dinnerservice "dinner/internal/services/dinner"
+	"log/slog"
+	"strings"
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Данные кнопки "Почему?" под ужином
+const whyCallback = "why"
+
+// Сколько последних объяснений подбора хранится в памяти
+const traceCacheSize = 1000
+
+// Сообщение в чате
+type messageKey struct {
+	chatID    int64
+	messageID int
+}
+
+// traceCache хранит объяснения подбора последних ужинов по сообщениям с ними.
+// После перезапуска бота объяснения старых ужинов недоступны.
+type traceCache struct {
+	mu     sync.Mutex
+	traces map[messageKey]models.Trace
+	order  []messageKey
+}
+
+// put запоминает объяснение подбора ужина из сообщения key, самые старые вытесняются
+func (c *traceCache) put(key messageKey, trace models.Trace) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.traces == nil {
+		c.traces = make(map[messageKey]models.Trace, traceCacheSize)
+	}
+	if len(c.order) >= traceCacheSize {
+		delete(c.traces, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.traces[key] = trace
+	c.order = append(c.order, key)
+}
+
+// get отдает объяснение подбора ужина из сообщения key
+func (c *traceCache) get(key messageKey) (models.Trace, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	trace, ok := c.traces[key]
+	return trace, ok
+}
+
+// whyKeyboard - кнопка "Почему?" под сообщением с ужином
+func whyKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.WhyButton), whyCallback),
+	))
+}
+
+// CallbackQuery обрабатывает нажатия кнопок под сообщениями бота
+func (b *TelegramBot) CallbackQuery(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery) {
+	const op = "TelegramBot.CallbackQuery"
+	log := b.log.With(slog.String("op", op))
+
+	if _, err := bot.Request(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		log.Error("answer callback error", slog.Any("error", err))
+	}
+	if query.Message == nil {
+		return
+	}
+	if value, ok := strings.CutPrefix(query.Data, pickCallbackPrefix); ok {
+		b.pickCallback(bot, query, value)
+		return
+	}
+	if query.Data != whyCallback {
+		return
+	}
+	lang := b.prefs.Language(query.From.ID, query.From.LanguageCode)
+	text := i18n.T(lang, i18n.WhyExpired)
+	if trace, ok := b.traces.get(messageKey{chatID: query.Message.Chat.ID, messageID: query.Message.MessageID}); ok {
+		text = traceText(lang, trace)
+	}
+	msg := tgbotapi.NewMessage(query.Message.Chat.ID, text)
+	msg.ReplyToMessageID = query.Message.MessageID
+	if _, err := b.send(bot, msg); err != nil {
+		log.Error("send message error", slog.Any("error", err))
+		metrics.CommandsHandled.WithLabelValues(whyCallback, "error").Inc()
+		return
+	}
+	metrics.CommandsHandled.WithLabelValues(whyCallback, "ok").Inc()
+}
+
+// Тексты шагов отбора
+var traceStepKeys = map[string]i18n.Key{
+	models.TraceCatalog:      i18n.WhyCatalog,
+	models.TraceRestrictions: i18n.WhyRestrictions,
+	models.TraceSeason:       i18n.WhySeason,
+	models.TraceWeekday:      i18n.WhyWeekday,
+	models.TraceDinners:      i18n.WhyDinners,
+	models.TraceKcal:         i18n.WhyKcal,
+	models.TraceBudget:       i18n.WhyBudget,
+}
+
+// Тексты случайных выборов
+var traceDrawKeys = map[string]i18n.Key{
+	models.DrawDish:    i18n.WhyDrawDish,
+	models.DrawPair:    i18n.WhyDrawPair,
+	models.DrawStarter: i18n.WhyDrawStarter,
+	models.DrawSoup:    i18n.WhyDrawSoup,
+}
+
+// traceText объясняет подбор ужина на языке lang
+func traceText(lang i18n.Lang, trace models.Trace) string {
+	lines := []string{i18n.T(lang, i18n.WhyTitle)}
+	for _, step := range trace.Steps {
+		if key, ok := traceStepKeys[step.Name]; ok {
+			lines = append(lines, "• "+i18n.T(lang, key, step.Pool))
+		}
+	}
+	for _, draw := range trace.Draws {
+		key, ok := traceDrawKeys[draw.Name]
+		if !ok {
+			continue
+		}
+		names := make([]string, 0, len(draw.Chosen))
+		for _, food := range draw.Chosen {
+			names = append(names, i18n.FoodName(lang, food))
+		}
+		lines = append(lines, "• "+i18n.T(lang, key, strings.Join(names, " + "), draw.Weight, draw.Total, draw.Roll))
+	}
+	if trace.Strategy != "" && trace.Strategy != dinnerservice.StrategyRandom {
+		lines = append(lines, i18n.T(lang, i18n.WhyMode, modeName(lang, trace.Strategy)))
+	}
+	if trace.Seed != 0 {
+		lines = append(lines, i18n.T(lang, i18n.WhySeed, trace.Seed))
+	}
+	return strings.Join(lines, "\n")
+}
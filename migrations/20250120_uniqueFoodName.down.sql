@@ -0,0 +1 @@
+DROP INDEX foods_name_IDX;
\ No newline at end of file
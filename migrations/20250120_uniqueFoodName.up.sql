@@ -0,0 +1 @@
+CREATE UNIQUE INDEX foods_name_IDX ON foods (name);
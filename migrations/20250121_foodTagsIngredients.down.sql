@@ -0,0 +1,2 @@
+DROP TABLE food_ingredients;
+DROP TABLE food_tags;
\ No newline at end of file
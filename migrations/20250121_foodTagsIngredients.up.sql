@@ -0,0 +1,13 @@
+CREATE TABLE food_tags (
+	foodId INTEGER NOT NULL,
+	tag TEXT NOT NULL,
+	CONSTRAINT food_tags_PK PRIMARY KEY (foodId, tag),
+	CONSTRAINT food_tags_foods_FK FOREIGN KEY (foodId) REFERENCES foods(id) ON DELETE CASCADE
+);
+
+CREATE TABLE food_ingredients (
+	foodId INTEGER NOT NULL,
+	ingredient TEXT NOT NULL,
+	CONSTRAINT food_ingredients_PK PRIMARY KEY (foodId, ingredient),
+	CONSTRAINT food_ingredients_foods_FK FOREIGN KEY (foodId) REFERENCES foods(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP INDEX history_userId_IDX;
+DROP TABLE history_foods;
\ No newline at end of file
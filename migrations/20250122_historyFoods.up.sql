@@ -0,0 +1,8 @@
+CREATE TABLE history_foods (
+	historyId INTEGER NOT NULL,
+	foodId INTEGER NOT NULL,
+	CONSTRAINT history_foods_history_FK FOREIGN KEY (historyId) REFERENCES history(id) ON DELETE CASCADE,
+	CONSTRAINT history_foods_foods_FK FOREIGN KEY (foodId) REFERENCES foods(id) ON DELETE CASCADE
+);
+
+CREATE INDEX history_userId_IDX ON history (userId, dt);
@@ -0,0 +1,2 @@
+DROP TABLE admin_audit;
+DROP TABLE users;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE users (
+	id INTEGER NOT NULL PRIMARY KEY,
+	chatId INTEGER NOT NULL,
+	userName TEXT NOT NULL DEFAULT '',
+	firstSeen TEXT NOT NULL,
+	lastSeen TEXT NOT NULL,
+	banned INTEGER NOT NULL DEFAULT 0,
+	limitResetAt TEXT
+);
+
+CREATE TABLE admin_audit (
+	id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+	adminId INTEGER NOT NULL,
+	action TEXT NOT NULL,
+	args TEXT NOT NULL DEFAULT '',
+	dt TEXT NOT NULL
+);
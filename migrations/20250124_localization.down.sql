@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN lang;
+DROP TABLE food_names;
\ No newline at end of file
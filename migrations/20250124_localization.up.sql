@@ -0,0 +1,9 @@
+CREATE TABLE food_names (
+	foodId INTEGER NOT NULL,
+	lang TEXT NOT NULL,
+	name TEXT NOT NULL,
+	CONSTRAINT food_names_PK PRIMARY KEY (foodId, lang),
+	CONSTRAINT food_names_foods_FK FOREIGN KEY (foodId) REFERENCES foods(id) ON DELETE CASCADE
+);
+
+ALTER TABLE users ADD COLUMN lang TEXT NOT NULL DEFAULT '';
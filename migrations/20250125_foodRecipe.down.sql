@@ -0,0 +1 @@
+ALTER TABLE foods DROP COLUMN recipe;
\ No newline at end of file
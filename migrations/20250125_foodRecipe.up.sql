@@ -0,0 +1 @@
+ALTER TABLE foods ADD COLUMN recipe TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN kcalMax;
+ALTER TABLE users DROP COLUMN kcalMin;
+DROP TABLE food_nutrition;
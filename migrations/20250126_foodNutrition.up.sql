@@ -0,0 +1,12 @@
+CREATE TABLE food_nutrition (
+	foodId INTEGER NOT NULL,
+	kcal REAL NOT NULL,
+	protein REAL NOT NULL,
+	fat REAL NOT NULL,
+	carbs REAL NOT NULL,
+	CONSTRAINT food_nutrition_PK PRIMARY KEY (foodId),
+	CONSTRAINT food_nutrition_foods_FK FOREIGN KEY (foodId) REFERENCES foods(id) ON DELETE CASCADE
+);
+
+ALTER TABLE users ADD COLUMN kcalMin REAL NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN kcalMax REAL NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN timezone;
+DROP TABLE food_weekdays;
+DROP TABLE food_seasons;
@@ -0,0 +1,15 @@
+CREATE TABLE food_seasons (
+	foodId INTEGER NOT NULL,
+	season TEXT NOT NULL,
+	CONSTRAINT food_seasons_PK PRIMARY KEY (foodId, season),
+	CONSTRAINT food_seasons_foods_FK FOREIGN KEY (foodId) REFERENCES foods(id) ON DELETE CASCADE
+);
+
+CREATE TABLE food_weekdays (
+	foodId INTEGER NOT NULL,
+	weekday TEXT NOT NULL,
+	CONSTRAINT food_weekdays_PK PRIMARY KEY (foodId, weekday),
+	CONSTRAINT food_weekdays_foods_FK FOREIGN KEY (foodId) REFERENCES foods(id) ON DELETE CASCADE
+);
+
+ALTER TABLE users ADD COLUMN timezone TEXT NOT NULL DEFAULT '';
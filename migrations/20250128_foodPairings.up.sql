@@ -0,0 +1,8 @@
+CREATE TABLE food_pairings (
+	meatId INTEGER NOT NULL,
+	sideId INTEGER NOT NULL,
+	weight INTEGER NOT NULL,
+	CONSTRAINT food_pairings_PK PRIMARY KEY (meatId, sideId),
+	CONSTRAINT food_pairings_meat_FK FOREIGN KEY (meatId) REFERENCES foods(id) ON DELETE CASCADE,
+	CONSTRAINT food_pairings_side_FK FOREIGN KEY (sideId) REFERENCES foods(id) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+CREATE TABLE leftovers (
+	userId INTEGER NOT NULL,
+	foodId INTEGER NOT NULL,
+	until TEXT NOT NULL,
+	CONSTRAINT leftovers_PK PRIMARY KEY (userId, foodId),
+	CONSTRAINT leftovers_food_FK FOREIGN KEY (foodId) REFERENCES foods(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TABLE user_restrictions;
+DROP TABLE household_members;
+DROP TABLE households;
@@ -0,0 +1,17 @@
+CREATE TABLE households (
+	id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+	ownerId INTEGER NOT NULL UNIQUE,
+	code TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE household_members (
+	userId INTEGER NOT NULL PRIMARY KEY,
+	householdId INTEGER NOT NULL,
+	CONSTRAINT household_members_FK FOREIGN KEY (householdId) REFERENCES households(id) ON DELETE CASCADE
+);
+
+CREATE TABLE user_restrictions (
+	userId INTEGER NOT NULL,
+	item TEXT NOT NULL,
+	CONSTRAINT user_restrictions_PK PRIMARY KEY (userId, item)
+);
@@ -0,0 +1,2 @@
+DROP TABLE food_ratings;
+ALTER TABLE users DROP COLUMN mode;
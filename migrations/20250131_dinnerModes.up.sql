@@ -0,0 +1,9 @@
+ALTER TABLE users ADD COLUMN mode TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE food_ratings (
+	userId INTEGER NOT NULL,
+	foodId INTEGER NOT NULL,
+	rating INTEGER NOT NULL,
+	CONSTRAINT food_ratings_PK PRIMARY KEY (userId, foodId),
+	CONSTRAINT food_ratings_foods_FK FOREIGN KEY (foodId) REFERENCES foods(id) ON DELETE CASCADE
+);
@@ -0,0 +1,8 @@
+CREATE TABLE food_queue (
+	userId INTEGER NOT NULL,
+	position INTEGER NOT NULL,
+	foodId INTEGER NOT NULL,
+	served INTEGER NOT NULL DEFAULT 0,
+	CONSTRAINT food_queue_PK PRIMARY KEY (userId, position),
+	CONSTRAINT food_queue_foods_FK FOREIGN KEY (foodId) REFERENCES foods(id) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE foods DROP COLUMN photo;
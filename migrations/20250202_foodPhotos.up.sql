@@ -0,0 +1 @@
+ALTER TABLE foods ADD COLUMN photo TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+DROP TABLE ingredient_prices;
+ALTER TABLE users DROP COLUMN weeklyBudget;
+ALTER TABLE foods DROP COLUMN price;
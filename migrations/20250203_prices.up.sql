@@ -0,0 +1,7 @@
+ALTER TABLE foods ADD COLUMN price INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN weeklyBudget INTEGER NOT NULL DEFAULT 0;
+CREATE TABLE ingredient_prices (
+	ingredient TEXT NOT NULL,
+	price INTEGER NOT NULL,
+	CONSTRAINT ingredient_prices_PK PRIMARY KEY (ingredient)
+);
@@ -0,0 +1,15 @@
+CREATE TABLE history_foods_old (
+	historyId INTEGER NOT NULL,
+	foodId INTEGER NOT NULL,
+	CONSTRAINT history_foods_history_FK FOREIGN KEY (historyId) REFERENCES history(id) ON DELETE CASCADE,
+	CONSTRAINT history_foods_foods_FK FOREIGN KEY (foodId) REFERENCES foods(id) ON DELETE CASCADE
+);
+
+INSERT INTO history_foods_old (historyId, foodId)
+SELECT hf.historyId, hf.foodId
+FROM history_foods hf
+JOIN foods f ON f.id = hf.foodId
+ORDER BY hf.rowid;
+
+DROP TABLE history_foods;
+ALTER TABLE history_foods_old RENAME TO history_foods;
@@ -0,0 +1,16 @@
+CREATE TABLE history_foods_new (
+	historyId INTEGER NOT NULL,
+	foodId INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	category INTEGER NOT NULL,
+	CONSTRAINT history_foods_history_FK FOREIGN KEY (historyId) REFERENCES history(id) ON DELETE CASCADE
+);
+
+INSERT INTO history_foods_new (historyId, foodId, name, category)
+SELECT hf.historyId, hf.foodId, f.name, f.category
+FROM history_foods hf
+JOIN foods f ON f.id = hf.foodId
+ORDER BY hf.rowid;
+
+DROP TABLE history_foods;
+ALTER TABLE history_foods_new RENAME TO history_foods;
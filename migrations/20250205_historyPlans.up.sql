@@ -0,0 +1 @@
+ALTER TABLE history ADD COLUMN plan INTEGER NOT NULL DEFAULT 0;
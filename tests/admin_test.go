@@ -0,0 +1,152 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	adminservice "dinner/internal/services/admin"
+	"dinner/internal/storages"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserProvider struct {
+	mock.Mock
+}
+
+func (m *MockUserProvider) SaveUser(user models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+func (m *MockUserProvider) IsBanned(userId int64) (bool, error) {
+	args := m.Called(userId)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockUserProvider) SetBanned(userId int64, banned bool) error {
+	args := m.Called(userId, banned)
+	return args.Error(0)
+}
+func (m *MockUserProvider) ResetLimit(userId int64, t time.Time) error {
+	args := m.Called(userId, t)
+	return args.Error(0)
+}
+func (m *MockUserProvider) GetUsers() ([]models.User, error) {
+	args := m.Called()
+	return args.Get(0).([]models.User), args.Error(1)
+}
+func (m *MockUserProvider) SaveAudit(entry models.AuditEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+func (m *MockUserProvider) GetUserLang(userId int64) (string, error) {
+	args := m.Called(userId)
+	return args.String(0), args.Error(1)
+}
+func (m *MockUserProvider) SetUserLang(userId int64, lang string) error {
+	args := m.Called(userId, lang)
+	return args.Error(0)
+}
+func (m *MockUserProvider) GetKcalTarget(userId int64) (models.KcalRange, error) {
+	args := m.Called(userId)
+	return args.Get(0).(models.KcalRange), args.Error(1)
+}
+func (m *MockUserProvider) SetKcalTarget(userId int64, target models.KcalRange) error {
+	args := m.Called(userId, target)
+	return args.Error(0)
+}
+func (m *MockUserProvider) GetBudget(userId int64) (int, error) {
+	args := m.Called(userId)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockUserProvider) SetBudget(userId int64, budget int) error {
+	args := m.Called(userId, budget)
+	return args.Error(0)
+}
+func (m *MockUserProvider) GetTimezone(userId int64) (string, error) {
+	args := m.Called(userId)
+	return args.String(0), args.Error(1)
+}
+func (m *MockUserProvider) SetTimezone(userId int64, timezone string) error {
+	args := m.Called(userId, timezone)
+	return args.Error(0)
+}
+func (m *MockUserProvider) GetMode(userId int64) (string, error) {
+	args := m.Called(userId)
+	return args.String(0), args.Error(1)
+}
+func (m *MockUserProvider) SetMode(userId int64, mode string) error {
+	args := m.Called(userId, mode)
+	return args.Error(0)
+}
+
+const testAdminId = 100
+
+func TestAdminBan(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	tests := []struct {
+		name    string
+		adminId int64
+		userId  int64
+		storage error
+		err     error
+		audited bool
+	}{
+		{name: "ban user", adminId: testAdminId, userId: 1, audited: true},
+		{name: "not admin", adminId: 2, userId: 1, err: services.ErrAccessDenied},
+		{name: "ban admin", adminId: testAdminId, userId: testAdminId, err: services.ErrAccessDenied, audited: true},
+		{name: "unknown user", adminId: testAdminId, userId: 1, storage: storages.ErrUserNotFound, err: services.ErrUserNotFound, audited: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := new(MockUserProvider)
+			provider.On("SaveAudit", mock.Anything).Return(nil)
+			provider.On("SetBanned", tt.userId, true).Return(tt.storage)
+			admin := adminservice.New(log, []int64{testAdminId}, provider)
+
+			err := admin.SetBanned(tt.adminId, tt.userId, true)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.Nil(t, err)
+				provider.AssertCalled(t, "SetBanned", tt.userId, true)
+			}
+			if tt.audited {
+				provider.AssertCalled(t, "SaveAudit", mock.MatchedBy(func(entry models.AuditEntry) bool {
+					return entry.AdminID == tt.adminId && entry.Action == adminservice.ActionBan
+				}))
+			} else {
+				provider.AssertNotCalled(t, "SaveAudit", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAdminCountUsersAndBroadcast(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	provider := new(MockUserProvider)
+	provider.On("SaveAudit", mock.Anything).Return(nil)
+	provider.On("GetUsers").Return([]models.User{
+		{ID: 1, ChatID: 11, LastSeen: time.Now()},
+		{ID: 2, ChatID: 12, LastSeen: time.Now().AddDate(0, -1, 0)},
+		{ID: 3, ChatID: 13, LastSeen: time.Now(), Banned: true},
+	}, nil)
+	admin := adminservice.New(log, []int64{testAdminId}, provider)
+
+	count, err := admin.CountUsers(testAdminId)
+	assert.Nil(t, err)
+	assert.Equal(t, models.UsersCount{Total: 3, Active: 1, Banned: 1}, count)
+
+	chats, err := admin.BroadcastTargets(testAdminId, "Привет")
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{11, 12}, chats)
+
+	_, err = admin.BroadcastTargets(1, "Привет")
+	assert.ErrorIs(t, err, services.ErrAccessDenied)
+}
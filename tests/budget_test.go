@@ -0,0 +1,142 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	catalogservice "dinner/internal/services/catalog"
+	dinnerservice "dinner/internal/services/dinner"
+	preferencesservice "dinner/internal/services/preferences"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func pricedFoods() []models.Food {
+	return []models.Food{
+		{ID: 1, Name: "Уха", Category: models.Soup, Price: 300},
+		{ID: 2, Name: "Борщ", Category: models.Soup, Price: 150, Ingredients: []string{"свёкла", "капуста"}},
+		{ID: 3, Name: "Щи", Category: models.Soup, Ingredients: []string{"капуста", "картофель", "укроп"}},
+		{ID: 4, Name: "Солянка", Category: models.Soup, Ingredients: []string{"колбаса"}},
+	}
+}
+
+func testPrices() models.Prices {
+	return models.Prices{"капуста": 40, "картофель": 30, "свекла": 20}
+}
+
+func TestPrices(t *testing.T) {
+	foods, prices := pricedFoods(), testPrices()
+
+	// Цена блюда важнее цен ингредиентов
+	cost, ok := prices.FoodCost(foods[1])
+	assert.True(t, ok)
+	assert.Equal(t, 150, cost)
+	// Неизвестные ингредиенты не учитываются
+	cost, ok = prices.FoodCost(foods[2])
+	assert.True(t, ok)
+	assert.Equal(t, 70, cost)
+	_, ok = prices.FoodCost(foods[3])
+	assert.False(t, ok)
+
+	cost, ok = prices.DinnerCost(foods[:3])
+	assert.True(t, ok)
+	assert.Equal(t, 520, cost)
+	_, ok = prices.DinnerCost(foods)
+	assert.False(t, ok)
+	_, ok = prices.DinnerCost(nil)
+	assert.False(t, ok)
+	assert.Equal(t, "свекла", models.PriceKey(" Свёкла "))
+}
+
+func TestGetDinnerBudget(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(pricedFoods(), nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("SavePlanRequest", mock.Anything, mock.Anything).Return(nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, dinnerservice.NewSource(1))
+
+	// Дешевый ужин без бюджета - не дороже медианы (150 ₽), блюда без цены не предлагаются
+	for range 10 {
+		dinner, err := dinnerService.GetDinner(1, dinnerservice.Options{Cheap: true, Prices: testPrices()})
+		require.NoError(t, err)
+		assert.Contains(t, []string{"Борщ", "Щи"}, dinner[0].Name)
+	}
+	// Бюджет 700 ₽ в неделю - не дороже 100 ₽ за ужин
+	for range 10 {
+		dinner, err := dinnerService.GetDinner(1, dinnerservice.Options{Budget: 700, Prices: testPrices()})
+		require.NoError(t, err)
+		assert.Equal(t, "Щи", dinner[0].Name)
+	}
+	// В бюджет ничего не укладывается: без cheap бюджет не мешает подобрать ужин
+	_, err := dinnerService.GetDinner(1, dinnerservice.Options{Budget: 70, Prices: testPrices()})
+	assert.NoError(t, err)
+	_, err = dinnerService.GetDinner(1, dinnerservice.Options{Budget: 70, Cheap: true, Prices: testPrices()})
+	assert.ErrorIs(t, err, services.ErrNoMatchingDinner)
+
+	// Без известных цен бюджет не учитывается и ужины не перебираются
+	_, trace, err := dinnerService.GetDinnerTrace(1, dinnerservice.Options{Budget: 700, Exclude: []string{"уха", "борщ"}})
+	require.NoError(t, err)
+	for _, step := range trace.Steps {
+		assert.NotContains(t, []string{models.TraceDinners, models.TraceBudget}, step.Name)
+	}
+	_, trace, err = dinnerService.GetDinnerTrace(1, dinnerservice.Options{Budget: 700, Prices: testPrices()})
+	require.NoError(t, err)
+	assert.Equal(t, models.TraceBudget, trace.Steps[len(trace.Steps)-1].Name)
+
+	// Бюджет на 2 дня - 200 ₽, в первый день не больше 100 ₽
+	for range 10 {
+		plan, err := dinnerService.GetPlan(1, 2, dinnerservice.Options{Budget: 700, Prices: testPrices()})
+		require.NoError(t, err)
+		require.Len(t, plan, 2)
+		assert.Equal(t, "Щи", plan[0][0].Name)
+	}
+}
+
+func TestCatalogSetPrice(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	storage := new(MockCatalogStorage)
+	storage.On("GetFoods").Return(pricedFoods(), nil)
+	storage.On("SetFoodPrice", int64(2), 200).Return(nil)
+	storage.On("SetIngredientPrice", "капуста", 50).Return(nil)
+	catalog := catalogservice.New(log, storage)
+
+	food, isFood, err := catalog.SetPrice("борщ", 200)
+	require.NoError(t, err)
+	assert.True(t, isFood)
+	assert.Equal(t, 200, food.Price)
+
+	_, isFood, err = catalog.SetPrice("Капуста", 50)
+	require.NoError(t, err)
+	assert.False(t, isFood)
+
+	_, _, err = catalog.SetPrice("ананас", 50)
+	assert.ErrorIs(t, err, services.ErrFoodNotFound)
+	for _, price := range []int{-1, catalogservice.MaxPrice + 1} {
+		_, _, err = catalog.SetPrice("борщ", price)
+		assert.ErrorIs(t, err, services.ErrInvalidPrice, price)
+	}
+	storage.AssertExpectations(t)
+}
+
+func TestPreferencesSetBudget(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	provider := new(MockUserProvider)
+	provider.On("SetBudget", int64(1), 3500).Return(nil)
+	provider.On("GetBudget", int64(1)).Return(3500, nil)
+	prefs := preferencesservice.New(log, provider)
+
+	require.NoError(t, prefs.SetBudget(1, 3500))
+	assert.Equal(t, 3500, prefs.Budget(1))
+	for _, budget := range []int{-1, preferencesservice.MaxBudget + 1} {
+		assert.ErrorIs(t, prefs.SetBudget(1, budget), services.ErrInvalidBudget, budget)
+	}
+	provider.AssertExpectations(t)
+}
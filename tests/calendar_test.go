@@ -0,0 +1,119 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	dinnerservice "dinner/internal/services/dinner"
+	preferencesservice "dinner/internal/services/preferences"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSeasonOf(t *testing.T) {
+	tests := []struct {
+		date     string
+		expected models.Season
+	}{
+		{date: "2025-01-15", expected: models.Winter},
+		{date: "2025-03-01", expected: models.Spring},
+		{date: "2025-07-20", expected: models.Summer},
+		{date: "2025-11-30", expected: models.Autumn},
+		{date: "2025-12-01", expected: models.Winter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			date, err := time.Parse(time.DateOnly, tt.date)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, models.SeasonOf(date))
+		})
+	}
+
+	seasons, err := models.ParseSeasons([]string{"Summer", "зима", "fall"})
+	assert.Nil(t, err)
+	assert.Equal(t, []models.Season{models.Summer, models.Winter, models.Autumn}, seasons)
+	_, err = models.ParseSeasons([]string{"monsoon"})
+	assert.NotNil(t, err)
+
+	days, err := models.ParseWeekdays([]string{"thu", "Sunday", "пт"})
+	assert.Nil(t, err)
+	assert.Equal(t, []time.Weekday{time.Thursday, time.Sunday, time.Friday}, days)
+	assert.Equal(t, []string{"thu", "sun", "fri"}, models.WeekdayNames(days))
+}
+
+func TestGetDinnerCalendar(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	foods := []models.Food{
+		{Name: "Okroshka", Category: models.Soup, Seasons: []models.Season{models.Summer}},
+		{Name: "Stew", Category: models.Soup, Seasons: []models.Season{models.Autumn, models.Winter}},
+		{Name: "Fish", Category: models.Salad, Weekdays: []time.Weekday{time.Thursday}},
+		{Name: "Borscht", Category: models.Soup},
+	}
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, nil)
+
+	// 2025-01-16 - четверг зимой, 2025-07-14 - понедельник летом
+	thursday := time.Date(2025, time.January, 16, 19, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, time.July, 14, 19, 0, 0, 0, time.UTC)
+
+	counts := map[time.Time]map[string]int{thursday: {}, monday: {}}
+	const runs = 300
+	for seed := uint64(1); seed <= runs; seed++ {
+		for date := range counts {
+			dinner, err := dinnerService.WithSeed(seed).GetDinner(1, dinnerservice.Options{Date: date})
+			assert.Nil(t, err)
+			counts[date][dinner[0].Name]++
+		}
+	}
+	// Блюда не по сезону не предлагаются
+	assert.Zero(t, counts[thursday]["Okroshka"])
+	assert.Zero(t, counts[monday]["Stew"])
+	// В четверг рыба предлагается в WeekdayBoost раз чаще остальных блюд:
+	// ожидается 3/5 запросов против 1/3 в понедельник
+	assert.Greater(t, counts[thursday]["Fish"], runs/2)
+	assert.Less(t, counts[monday]["Fish"], runs/2)
+
+	// Без даты календарь не учитывается
+	seen := map[string]bool{}
+	for seed := uint64(1); seed <= 50; seed++ {
+		dinner, err := dinnerService.WithSeed(seed).GetRandomDinner(1)
+		assert.Nil(t, err)
+		seen[dinner[0].Name] = true
+	}
+	assert.Len(t, seen, len(foods))
+
+	// Если по сезону нет ни одного блюда, предлагается весь каталог
+	summerOnly := []models.Food{foods[0]}
+	provider := new(MockFoodProvider)
+	provider.On("GetFoods").Return(summerOnly, nil)
+	dinner, err := dinnerservice.New(log, provider, mockHistoryProvider, nil, nil, nil).GetDinner(1, dinnerservice.Options{Date: thursday})
+	assert.Nil(t, err)
+	assert.Equal(t, summerOnly, dinner)
+}
+
+func TestAdminTimezone(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	provider := new(MockUserProvider)
+	provider.On("SetTimezone", int64(1), mock.Anything).Return(nil)
+	provider.On("GetTimezone", int64(1)).Return("Asia/Tokyo", nil)
+	prefs := preferencesservice.New(log, provider)
+
+	assert.Nil(t, prefs.SetTimezone(1, "Asia/Tokyo"))
+	provider.AssertCalled(t, "SetTimezone", int64(1), "Asia/Tokyo")
+	assert.Nil(t, prefs.SetTimezone(1, "auto"))
+	provider.AssertCalled(t, "SetTimezone", int64(1), "")
+	assert.ErrorIs(t, prefs.SetTimezone(1, "Mars/Olympus"), services.ErrUnknownTimezone)
+	assert.ErrorIs(t, prefs.SetTimezone(1, "Local"), services.ErrUnknownTimezone)
+
+	assert.Equal(t, "Asia/Tokyo", prefs.Now(1).Location().String())
+}
@@ -0,0 +1,179 @@
+package dinner
+
+import (
+	"bytes"
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	catalogservice "dinner/internal/services/catalog"
+	"dinner/internal/storages"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCatalogStorage struct {
+	mock.Mock
+}
+
+func (m *MockCatalogStorage) GetFoods() ([]models.Food, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Food), args.Error(1)
+}
+func (m *MockCatalogStorage) GetCategories() ([]models.Category, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Category), args.Error(1)
+}
+func (m *MockCatalogStorage) SaveFoods(foods []models.Food) error {
+	args := m.Called(foods)
+	return args.Error(0)
+}
+func (m *MockCatalogStorage) CreateFood(food models.Food) (int64, error) {
+	args := m.Called(food)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockCatalogStorage) UpdateFood(food models.Food) error {
+	args := m.Called(food)
+	return args.Error(0)
+}
+func (m *MockCatalogStorage) SetFoodPhoto(id int64, photo string) error {
+	args := m.Called(id, photo)
+	return args.Error(0)
+}
+func (m *MockCatalogStorage) SetFoodPrice(id int64, price int) error {
+	args := m.Called(id, price)
+	return args.Error(0)
+}
+func (m *MockCatalogStorage) GetIngredientPrices() (models.Prices, error) {
+	args := m.Called()
+	return args.Get(0).(models.Prices), args.Error(1)
+}
+func (m *MockCatalogStorage) SetIngredientPrice(ingredient string, price int) error {
+	args := m.Called(ingredient, price)
+	return args.Error(0)
+}
+func (m *MockCatalogStorage) DeleteFood(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+func (m *MockCatalogStorage) GetPairings() ([]models.Pairing, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Pairing), args.Error(1)
+}
+func (m *MockCatalogStorage) SetPairing(pairing models.Pairing) error {
+	args := m.Called(pairing)
+	return args.Error(0)
+}
+
+var catalogCategories = []models.Category{
+	{ID: models.Soup, Name: "Суп"},
+	{ID: models.Meat, Name: "Мясо"},
+}
+
+var catalogFoods = []models.Food{
+	{Name: `Суп "Борщ"`, Category: models.Soup, Tags: []string{"красный"}, Ingredients: []string{"капуста", "свекла"}, Names: map[string]string{"en": `Soup "Borscht"`}, Seasons: []models.Season{models.Winter, models.Autumn}},
+	{Name: "Котлеты", Category: models.Meat, Nutrition: &models.Nutrition{Kcal: 400, Protein: 22, Fat: 28.5, Carbs: 14}, Weekdays: []time.Weekday{time.Thursday}},
+}
+
+func TestCatalogRoundTrip(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	for _, format := range []catalogservice.Format{catalogservice.FormatCSV, catalogservice.FormatJSON, catalogservice.FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			storage := new(MockCatalogStorage)
+			storage.On("GetFoods").Return(catalogFoods, nil)
+			storage.On("GetCategories").Return(catalogCategories, nil)
+			catalog := catalogservice.New(log, storage)
+
+			var buf bytes.Buffer
+			assert.Nil(t, catalog.Export(format, &buf))
+
+			diff, err := catalog.Import(format, &buf, false)
+			assert.Nil(t, err)
+			assert.Empty(t, diff.Added)
+			assert.Empty(t, diff.Updated)
+			assert.Equal(t, len(catalogFoods), diff.Unchanged)
+			storage.AssertNotCalled(t, "SaveFoods", mock.Anything)
+		})
+	}
+}
+
+func TestCatalogImport(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	tests := []struct {
+		name    string
+		data    string
+		added   int
+		updated int
+		err     error
+	}{
+		{
+			name:  "new food",
+			data:  "name,category,tags,ingredients\nПлов,мясо,,рис;баранина\n",
+			added: 1,
+		},
+		{
+			name:    "changed tags",
+			data:    "name,category,tags,ingredients\nКотлеты,Мясо,Детское,\n",
+			updated: 1,
+		},
+		{
+			name:    "short header with translation",
+			data:    "name,names,category\nКотлеты,en=Cutlets,Мясо\n",
+			updated: 1,
+		},
+		{
+			name: "unknown category",
+			data: "name,category,tags,ingredients\nОливье,Салат,,\n",
+			err:  services.ErrInvalidCatalog,
+		},
+		{
+			name: "empty name",
+			data: "name,category,tags,ingredients\n ,Мясо,,\n",
+			err:  services.ErrInvalidCatalog,
+		},
+		{
+			name: "unknown column",
+			data: "name,category,price\nКотлеты,Мясо,100\n",
+			err:  services.ErrInvalidCatalog,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := new(MockCatalogStorage)
+			storage.On("GetFoods").Return(catalogFoods, nil)
+			storage.On("GetCategories").Return(catalogCategories, nil)
+			catalog := catalogservice.New(log, storage)
+
+			diff, err := catalog.Import(catalogservice.FormatCSV, strings.NewReader(tt.data), true)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, diff.Added, tt.added)
+			assert.Len(t, diff.Updated, tt.updated)
+			storage.AssertNotCalled(t, "SaveFoods", mock.Anything)
+		})
+	}
+}
+
+func TestCatalogSetPhoto(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	storage := new(MockCatalogStorage)
+	storage.On("SetFoodPhoto", int64(1), "AgACAgIAAx").Return(nil)
+	storage.On("SetFoodPhoto", int64(1), "").Return(nil)
+	storage.On("SetFoodPhoto", int64(2), mock.Anything).Return(storages.ErrFoodNotFound)
+	catalog := catalogservice.New(log, storage)
+
+	assert.NoError(t, catalog.SetPhoto(1, "AgACAgIAAx"))
+	assert.NoError(t, catalog.SetPhoto(1, ""))
+	assert.ErrorIs(t, catalog.SetPhoto(2, "AgACAgIAAx"), services.ErrFoodNotFound)
+	storage.AssertExpectations(t)
+}
@@ -0,0 +1,67 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/stem"
+	dinnerservice "dinner/internal/services/dinner"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStemMatch(t *testing.T) {
+	tests := []struct {
+		have       string
+		ingredient string
+		expected   bool
+	}{
+		{have: "курицу", ingredient: "курица", expected: true},
+		{have: "Курицей", ingredient: "курица", expected: true},
+		{have: "луком", ingredient: "лук", expected: true},
+		{have: "огурец", ingredient: "огурцы соленые", expected: true},
+		{have: "картошка", ingredient: "картофель", expected: true},
+		{have: "яиц", ingredient: "яйца", expected: true},
+		{have: "сыр", ingredient: "сыр фета", expected: true},
+		{have: "сыр фета", ingredient: "сыр", expected: false},
+		{have: "рис", ingredient: "рыба", expected: false},
+		{have: "", ingredient: "рис", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.have+"/"+tt.ingredient, func(t *testing.T) {
+			assert.Equal(t, tt.expected, stem.Match(tt.have, tt.ingredient))
+		})
+	}
+}
+
+func TestCook(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	foods := []models.Food{
+		{Name: "Борщ", Category: models.Soup, Ingredients: []string{"капуста", "картофель", "лук", "морковь", "свекла"}},
+		{Name: "Вареная курица", Category: models.Meat, Ingredients: []string{"курица"}},
+		{Name: "Рис", Category: models.SideDish, Ingredients: []string{"рис"}},
+		{Name: "Пюре", Category: models.SideDish, Ingredients: []string{"картофель", "молоко"}},
+		{Name: "Оливье", Category: models.Salad},
+	}
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, nil)
+	options, err := dinnerService.Cook([]string{"курицу", "рис", "картошка"}, 0)
+	assert.Nil(t, err)
+
+	// Курица с рисом - все есть, курица с пюре - не хватает молока,
+	// борщ покрыт меньше чем наполовину, оливье без ингредиентов
+	assert.Len(t, options, 2)
+	assert.Equal(t, []models.Food{foods[1], foods[2]}, options[0].Foods)
+	assert.Equal(t, 1.0, options[0].Coverage)
+	assert.Empty(t, options[0].Missing)
+	assert.Equal(t, []models.Food{foods[1], foods[3]}, options[1].Foods)
+	assert.Equal(t, []string{"молоко"}, options[1].Missing)
+	// Подбор по продуктам не входит в лимит
+	mockHistoryProvider.AssertNotCalled(t, "IsLimit")
+	mockHistoryProvider.AssertNotCalled(t, "SaveRequest")
+}
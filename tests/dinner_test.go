@@ -26,14 +26,31 @@ type MockHistoryProvider struct {
 	mock.Mock
 }
 
-func (m *MockHistoryProvider) SaveRequest(userId int64) error {
-	args := m.Called(userId)
+func (m *MockHistoryProvider) SaveRequest(userId int64, foods []models.Food) error {
+	args := m.Called(userId, foods)
+	return args.Error(0)
+}
+func (m *MockHistoryProvider) SavePlanRequest(userId int64, foods []models.Food) error {
+	args := m.Called(userId, foods)
 	return args.Error(0)
 }
 func (m *MockHistoryProvider) IsLimit(userId int64) (bool, error) {
 	args := m.Called(userId)
 	return args.Get(0).(bool), args.Error(1)
 }
+
+// Зерно, с которым тесты проверяют, какие именно блюда выбраны
+const testSeed = 2
+
+// foodNames отдает названия блюд по порядку
+func foodNames(foods []models.Food) []string {
+	names := make([]string, 0, len(foods))
+	for _, food := range foods {
+		names = append(names, food.Name)
+	}
+	return names
+}
+
 func TestGetSideDishesEmpty(t *testing.T) {
 	foods := []models.Food{}
 	actual := dinnerservice.GetSideDishes(&foods)
@@ -49,10 +66,10 @@ func TestGetRandomDinnerIsLimit(t *testing.T) {
 	mockFoodProvider.On("GetFoods").Return(nil, nil)
 
 	mockHistoryProvider := new(MockHistoryProvider)
-	mockHistoryProvider.On("SaveRequest", mock.Anything).Return(nil)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
 	mockHistoryProvider.On("IsLimit", mock.Anything).Return(false, nil)
 
-	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, nil)
 	_, err := dinnerService.GetRandomDinner(1)
 
 	if !errors.Is(err, services.ErrAttemptLimitExceeded) {
@@ -67,10 +84,10 @@ func TestGetRandomDinnerNilFood(t *testing.T) {
 	mockFoodProvider.On("GetFoods").Return(foodNil, nil)
 
 	mockHistoryProvider := new(MockHistoryProvider)
-	mockHistoryProvider.On("SaveRequest", mock.Anything).Return(nil)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
 	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
 
-	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, nil)
 	_, err := dinnerService.GetRandomDinner(1)
 
 	if !errors.Is(err, services.ErrEmptyFood) {
@@ -84,10 +101,10 @@ func TestGetRandomDinnerEmptyFood(t *testing.T) {
 	mockFoodProvider.On("GetFoods").Return([]models.Food{}, nil)
 
 	mockHistoryProvider := new(MockHistoryProvider)
-	mockHistoryProvider.On("SaveRequest", mock.Anything).Return(nil)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
 	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
 
-	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, nil)
 	_, err := dinnerService.GetRandomDinner(1)
 
 	if !errors.Is(err, services.ErrEmptyFood) {
@@ -102,6 +119,8 @@ func TestGetRandomDinnerCorrectFood(t *testing.T) {
 		name     string
 		foods    []models.Food
 		expected int
+		// Блюда, которые выбирает зерно testSeed
+		names []string
 	}{
 		{
 			name: "one food salad",
@@ -112,6 +131,7 @@ func TestGetRandomDinnerCorrectFood(t *testing.T) {
 				},
 			},
 			expected: 1,
+			names:    []string{"Salad1"},
 		},
 		{
 			name: "two food salad",
@@ -126,6 +146,7 @@ func TestGetRandomDinnerCorrectFood(t *testing.T) {
 				},
 			},
 			expected: 1,
+			names:    []string{"Salad2"},
 		},
 		{
 			name: "one food soup",
@@ -136,6 +157,7 @@ func TestGetRandomDinnerCorrectFood(t *testing.T) {
 				},
 			},
 			expected: 1,
+			names:    []string{"Soup1"},
 		},
 		{
 			name: "two food soup",
@@ -150,6 +172,7 @@ func TestGetRandomDinnerCorrectFood(t *testing.T) {
 				},
 			},
 			expected: 1,
+			names:    []string{"Soup2"},
 		},
 		{
 			name: "soup and salat",
@@ -164,6 +187,7 @@ func TestGetRandomDinnerCorrectFood(t *testing.T) {
 				},
 			},
 			expected: 1,
+			names:    []string{"Salad1"},
 		},
 		{
 			name: "two foods",
@@ -178,6 +202,7 @@ func TestGetRandomDinnerCorrectFood(t *testing.T) {
 				},
 			},
 			expected: 2,
+			names:    []string{"SideDish1", "Meat1"},
 		},
 		{
 			name: "two meats and one sidedish",
@@ -196,6 +221,7 @@ func TestGetRandomDinnerCorrectFood(t *testing.T) {
 				},
 			},
 			expected: 2,
+			names:    []string{"Meat1", "SideDish1"},
 		},
 		{
 			name: "one meat and two sidedishes",
@@ -214,6 +240,7 @@ func TestGetRandomDinnerCorrectFood(t *testing.T) {
 				},
 			},
 			expected: 2,
+			names:    []string{"Meat1", "SideDish1"},
 		},
 		{
 			name: "two meats",
@@ -228,6 +255,7 @@ func TestGetRandomDinnerCorrectFood(t *testing.T) {
 				},
 			},
 			expected: 1,
+			names:    []string{"Meat2"},
 		},
 		{
 			name: "two sideDishes",
@@ -242,22 +270,24 @@ func TestGetRandomDinnerCorrectFood(t *testing.T) {
 				},
 			},
 			expected: 1,
+			names:    []string{"SideDish2"},
 		},
 	}
 
 	mockHistoryProvider := new(MockHistoryProvider)
-	mockHistoryProvider.On("SaveRequest", mock.Anything).Return(nil)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
 	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockFoodProvider := new(MockFoodProvider)
 			mockFoodProvider.On("GetFoods").Return(tt.foods, nil)
-			dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider)
+			dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, nil)
 
-			foods, err := dinnerService.GetRandomDinner(1)
+			foods, err := dinnerService.WithSeed(testSeed).GetRandomDinner(1)
 			assert.Nil(t, err)
 			assert.Len(t, foods, tt.expected)
+			assert.Equal(t, tt.names, foodNames(foods))
 		})
 	}
 }
@@ -269,6 +299,8 @@ func TestGetRandomDinnerCorrectTwoFood(t *testing.T) {
 		name     string
 		foods    []models.Food
 		expected int
+		// Блюда, которые выбирает зерно testSeed
+		names []string
 	}{
 		{
 			name: "two foods",
@@ -283,6 +315,7 @@ func TestGetRandomDinnerCorrectTwoFood(t *testing.T) {
 				},
 			},
 			expected: 2,
+			names:    []string{"SideDish1", "Meat1"},
 		},
 		{
 			name: "two meats and one sidedish",
@@ -301,6 +334,7 @@ func TestGetRandomDinnerCorrectTwoFood(t *testing.T) {
 				},
 			},
 			expected: 2,
+			names:    []string{"Meat1", "SideDish1"},
 		},
 		{
 			name: "one meat and two sidedishes",
@@ -319,6 +353,7 @@ func TestGetRandomDinnerCorrectTwoFood(t *testing.T) {
 				},
 			},
 			expected: 2,
+			names:    []string{"Meat1", "SideDish1"},
 		},
 		{
 			name: "two meats and two sidedishes",
@@ -341,23 +376,114 @@ func TestGetRandomDinnerCorrectTwoFood(t *testing.T) {
 				},
 			},
 			expected: 2,
+			names:    []string{"SideDish2", "Meat1"},
 		},
 	}
 
 	mockHistoryProvider := new(MockHistoryProvider)
-	mockHistoryProvider.On("SaveRequest", mock.Anything).Return(nil)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
 	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockFoodProvider := new(MockFoodProvider)
 			mockFoodProvider.On("GetFoods").Return(tt.foods, nil)
-			dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider)
+			dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, nil)
 
-			foods, err := dinnerService.GetRandomDinner(1)
+			foods, err := dinnerService.WithSeed(testSeed).GetRandomDinner(1)
 			assert.Nil(t, err)
 			assert.Len(t, foods, tt.expected)
+			assert.Equal(t, tt.names, foodNames(foods))
 			assert.True(t, (foods[0].Category == models.Meat && foods[1].Category == models.SideDish) || (foods[1].Category == models.Meat && foods[0].Category == models.SideDish))
 		})
 	}
 }
+
+func TestGetWeeklyPlanNoRepeats(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	foods := []models.Food{
+		{Name: "Soup1", Category: models.Soup},
+		{Name: "Soup2", Category: models.Soup},
+		{Name: "Salad1", Category: models.Salad},
+		{Name: "Salad2", Category: models.Salad},
+		{Name: "Meat1", Category: models.Meat},
+		{Name: "Garnish1", Category: models.SideDish},
+	}
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SavePlanRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, nil)
+	plan, err := dinnerService.GetWeeklyPlan(1, 4)
+	assert.Nil(t, err)
+	assert.Len(t, plan, 4)
+
+	seen := map[string]bool{}
+	for _, dinner := range plan {
+		for _, food := range dinner {
+			assert.False(t, seen[food.Name], "food %q repeated", food.Name)
+			seen[food.Name] = true
+		}
+	}
+	// План - один запрос в истории
+	mockHistoryProvider.AssertNumberOfCalls(t, "SavePlanRequest", 1)
+	mockHistoryProvider.AssertNumberOfCalls(t, "IsLimit", 1)
+
+	_, err = dinnerService.GetWeeklyPlan(1, dinnerservice.MaxPlanDays+1)
+	assert.ErrorIs(t, err, services.ErrInvalidPlanDays)
+}
+
+func TestGetRandomDinnerSeed(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	foods := []models.Food{
+		{Name: "Soup1", Category: models.Soup},
+		{Name: "Salad1", Category: models.Salad},
+		{Name: "Meat1", Category: models.Meat},
+		{Name: "Meat2", Category: models.Meat},
+		{Name: "Garnish1", Category: models.SideDish},
+		{Name: "Garnish2", Category: models.SideDish},
+	}
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("SavePlanRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+
+	// Одинаковое зерно - одинаковые ужины и планы
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, nil)
+	for _, seed := range []uint64{1, 42, 2025} {
+		dinner, err := dinnerService.WithSeed(seed).GetRandomDinner(1)
+		assert.Nil(t, err)
+		again, err := dinnerService.WithSeed(seed).GetRandomDinner(1)
+		assert.Nil(t, err)
+		assert.Equal(t, dinner, again, "seed %d", seed)
+
+		first, err := dinnerService.WithSeed(seed).GetWeeklyPlan(1, 3)
+		assert.Nil(t, err)
+		second, err := dinnerService.WithSeed(seed).GetWeeklyPlan(1, 3)
+		assert.Nil(t, err)
+		assert.Equal(t, first, second, "seed %d", seed)
+	}
+	// Зерно выбирает одни и те же блюда
+	dinner, err := dinnerService.WithSeed(42).GetRandomDinner(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Meat2", "Garnish2"}, foodNames(dinner))
+	plan, err := dinnerService.WithSeed(42).GetWeeklyPlan(1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"Meat2", "Garnish2"}, {"Meat1", "Garnish1"}, {"Salad1"}}, [][]string{
+		foodNames(plan[0]), foodNames(plan[1]), foodNames(plan[2]),
+	})
+	// Общий источник с фиксированным зерном повторяет последовательность
+	a := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, dinnerservice.NewSource(7))
+	b := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, dinnerservice.NewSource(7))
+	for range 5 {
+		assert.Equal(t, a.NextSeed(), b.NextSeed())
+	}
+}
@@ -0,0 +1,130 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/formatter"
+	"dinner/internal/lib/i18n"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterDinner(t *testing.T) {
+	cutlets := models.Food{Name: "Котлеты", Category: models.Meat, Names: map[string]string{"en": "Cutlets"}}
+	rice := models.Food{Name: "Рис", Category: models.SideDish, Names: map[string]string{"en": "Rice"}}
+	olivier := models.Food{Name: `Салат "Оливье"`, Category: models.Salad}
+	borsch := models.Food{Name: `Суп "Борщ"`, Category: models.Soup}
+
+	tests := []struct {
+		name     string
+		mode     formatter.Mode
+		lang     i18n.Lang
+		foods    []models.Food
+		expected string
+	}{
+		{
+			name:     "single dish",
+			mode:     formatter.Plain,
+			lang:     i18n.Ru,
+			foods:    []models.Food{olivier},
+			expected: `🥗 Салат "Оливье"`,
+		},
+		{
+			name:     "meat and side",
+			mode:     formatter.Plain,
+			lang:     i18n.Ru,
+			foods:    []models.Food{cutlets, rice},
+			expected: "🍖 Котлеты и 🍚 рис",
+		},
+		{
+			name:     "side first is reordered",
+			mode:     formatter.Plain,
+			lang:     i18n.Ru,
+			foods:    []models.Food{rice, cutlets},
+			expected: "🍖 Котлеты и 🍚 рис",
+		},
+		{
+			name:     "english",
+			mode:     formatter.Plain,
+			lang:     i18n.En,
+			foods:    []models.Food{cutlets, rice},
+			expected: "🍖 Cutlets and 🍚 rice",
+		},
+		{
+			name:     "proper noun keeps case",
+			mode:     formatter.Plain,
+			lang:     i18n.Ru,
+			foods:    []models.Food{cutlets, {Name: "Пюре Робюшон", Category: models.SideDish, Tags: []string{formatter.ProperNounTag}}},
+			expected: "🍖 Котлеты и 🍚 Пюре Робюшон",
+		},
+		{
+			name:     "abbreviation keeps case",
+			mode:     formatter.Plain,
+			lang:     i18n.Ru,
+			foods:    []models.Food{cutlets, {Name: "BBQ овощи", Category: models.SideDish}},
+			expected: "🍖 Котлеты и 🍚 BBQ овощи",
+		},
+		{
+			name:     "empty name does not panic",
+			mode:     formatter.Plain,
+			lang:     i18n.Ru,
+			foods:    []models.Food{cutlets, {Name: "", Category: models.SideDish}},
+			expected: "🍖 Котлеты и 🍚 ",
+		},
+		{
+			name:     "courses",
+			mode:     formatter.Plain,
+			lang:     i18n.Ru,
+			foods:    []models.Food{cutlets, borsch, rice, olivier},
+			expected: "Закуска: 🥗 Салат \"Оливье\"\nПервое: 🍲 Суп \"Борщ\"\nОсновное: 🍖 Котлеты\nГарнир: 🍚 Рис",
+		},
+		{
+			name:     "plain recipe",
+			mode:     formatter.Plain,
+			lang:     i18n.Ru,
+			foods:    []models.Food{{Name: "Котлеты", Category: models.Meat, Recipe: "https://example.com/c"}},
+			expected: "🍖 Котлеты (https://example.com/c)",
+		},
+		{
+			name:     "markdown escaping",
+			mode:     formatter.MarkdownV2,
+			lang:     i18n.Ru,
+			foods:    []models.Food{{Name: "Рыба (жареная).", Category: models.Meat}},
+			expected: `🍖 Рыба \(жареная\)\.`,
+		},
+		{
+			name:     "markdown recipe",
+			mode:     formatter.MarkdownV2,
+			lang:     i18n.Ru,
+			foods:    []models.Food{{Name: "Котлеты", Category: models.Meat, Recipe: "https://example.com/a_(b)"}},
+			expected: `🍖 [Котлеты](https://example.com/a_(b\))`,
+		},
+		{
+			name:     "markdown courses",
+			mode:     formatter.MarkdownV2,
+			lang:     i18n.En,
+			foods:    []models.Food{olivier, borsch},
+			expected: "Starter: 🥗 Салат \"Оливье\"\nSoup: 🍲 Суп \"Борщ\"",
+		},
+		{
+			name:     "html escaping",
+			mode:     formatter.HTML,
+			lang:     i18n.Ru,
+			foods:    []models.Food{{Name: "Мясо <по-французски> & сыр", Category: models.Meat}},
+			expected: "🍖 Мясо &lt;по-французски&gt; &amp; сыр",
+		},
+		{
+			name:     "html recipe",
+			mode:     formatter.HTML,
+			lang:     i18n.Ru,
+			foods:    []models.Food{cutlets, {Name: "Рис", Category: models.SideDish, Recipe: "https://example.com/?a=1&b=2"}},
+			expected: `🍖 Котлеты и 🍚 <a href="https://example.com/?a=1&amp;b=2">рис</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatter.New(tt.mode).Dinner(tt.lang, tt.foods))
+		})
+	}
+}
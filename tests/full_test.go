@@ -0,0 +1,144 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/formatter"
+	"dinner/internal/lib/i18n"
+	dinnerservice "dinner/internal/services/dinner"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fullDinnerFoods - каталог, из которого можно собрать ужин из всех подач
+func fullDinnerFoods() []models.Food {
+	return []models.Food{
+		{ID: 1, Name: "Борщ", Category: models.Soup, Nutrition: &models.Nutrition{Kcal: 250}},
+		{ID: 2, Name: "Щи", Category: models.Soup, Nutrition: &models.Nutrition{Kcal: 150}},
+		{ID: 3, Name: "Оливье", Category: models.Salad, Nutrition: &models.Nutrition{Kcal: 300}},
+		{ID: 4, Name: "Винегрет", Category: models.Salad, Ingredients: []string{"свекла"}, Nutrition: &models.Nutrition{Kcal: 100}},
+		{ID: 5, Name: "Котлеты", Category: models.Meat, Nutrition: &models.Nutrition{Kcal: 400}},
+		{ID: 6, Name: "Рис", Category: models.SideDish, Nutrition: &models.Nutrition{Kcal: 200}},
+		{ID: 7, Name: "Гречка", Category: models.SideDish, Nutrition: &models.Nutrition{Kcal: 150}},
+	}
+}
+
+func newFullDinnerService(foods []models.Food, pairings dinnerservice.PairingProvider) *dinnerservice.Dinner {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	return dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, pairings, nil, dinnerservice.NewSource(11))
+}
+
+// categories считает блюда ужина по категориям
+func categories(dinner []models.Food) map[models.FootCategory]int {
+	res := make(map[models.FootCategory]int)
+	for _, food := range dinner {
+		res[food.Category]++
+	}
+	return res
+}
+
+func TestGetDinnerFull(t *testing.T) {
+	dinnerService := newFullDinnerService(fullDinnerFoods(), nil)
+	for range 20 {
+		dinner, err := dinnerService.GetDinner(1, dinnerservice.Options{Full: true})
+		require.NoError(t, err)
+		assert.Equal(t, map[models.FootCategory]int{
+			models.Salad: 1, models.Soup: 1, models.Meat: 1, models.SideDish: 1,
+		}, categories(dinner))
+	}
+
+	// Ограничения и цель по калориям - для всего ужина
+	opts := dinnerservice.Options{Full: true, Exclude: []string{"свекла"}, Kcal: models.KcalRange{Max: 1050}}
+	for range 20 {
+		dinner, err := dinnerService.GetDinner(1, opts)
+		require.NoError(t, err)
+		total, ok := models.TotalNutrition(dinner)
+		require.True(t, ok)
+		assert.LessOrEqual(t, total.Kcal, 1050.0)
+		assert.NotContains(t, dinner, fullDinnerFoods()[3])
+		assert.Len(t, dinner, 4)
+	}
+}
+
+func TestGetDinnerFullPairings(t *testing.T) {
+	// Котлеты с рисом запрещены
+	pairings := new(MockPairingProvider)
+	pairings.On("GetPairings").Return([]models.Pairing{{MeatID: 5, SideID: 6, Weight: models.PairForbidden}}, nil)
+	dinnerService := newFullDinnerService(fullDinnerFoods(), pairings)
+	for _, opts := range []dinnerservice.Options{{Full: true}, {Full: true, Kcal: models.KcalRange{Max: 2000}}} {
+		for range 20 {
+			dinner, err := dinnerService.GetDinner(1, opts)
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(dinner))
+			for _, food := range dinner {
+				ids = append(ids, food.ID)
+			}
+			assert.False(t, slices.Contains(ids, 5) && slices.Contains(ids, 6), "forbidden pair in %v", ids)
+		}
+	}
+}
+
+func TestGetDinnerFullMissingCourse(t *testing.T) {
+	// Без салатов закуска пропускается
+	foods := fullDinnerFoods()
+	foods = append(foods[:2:2], foods[4:]...)
+	dinnerService := newFullDinnerService(foods, nil)
+	dinner, err := dinnerService.GetDinner(1, dinnerservice.Options{Full: true})
+	require.NoError(t, err)
+	assert.Equal(t, map[models.FootCategory]int{models.Soup: 1, models.Meat: 1, models.SideDish: 1}, categories(dinner))
+
+	text := formatter.New(formatter.Plain).Dinner(i18n.Ru, dinner)
+	assert.Regexp(t, "^Первое: .*\nОсновное: .*\nГарнир: ", text)
+}
+
+func TestGetDinnerFullLargeCatalog(t *testing.T) {
+	// По 30 тяжелых блюд каждой подачи и по одному легкому: сочетаний больше, чем составляется за раз
+	var foods []models.Food
+	for i, category := range []models.FootCategory{models.Soup, models.Salad, models.Meat, models.SideDish} {
+		for j := range 31 {
+			kcal := 1000.0
+			if j == 0 {
+				kcal = 100
+			}
+			foods = append(foods, models.Food{
+				ID:        int64(i*100 + j + 1),
+				Name:      fmt.Sprintf("Блюдо %d-%d", i, j),
+				Category:  category,
+				Nutrition: &models.Nutrition{Kcal: kcal},
+			})
+		}
+	}
+	dinnerService := newFullDinnerService(foods, nil)
+
+	// Подходит только ужин из легких блюд, и он находится несмотря на выборку
+	dinner, err := dinnerService.GetDinner(1, dinnerservice.Options{Full: true, Kcal: models.KcalRange{Max: 500}})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 101, 201, 301}, foodIDs(dinner))
+
+	for range 5 {
+		dinner, err := dinnerService.GetDinner(1, dinnerservice.Options{Full: true, Kcal: models.KcalRange{Min: 3000}})
+		require.NoError(t, err)
+		assert.Len(t, dinner, 4)
+	}
+}
+
+// foodIDs возвращает отсортированные id блюд ужина
+func foodIDs(dinner []models.Food) []int64 {
+	ids := make([]int64, 0, len(dinner))
+	for _, food := range dinner {
+		ids = append(ids, food.ID)
+	}
+	slices.Sort(ids)
+	return ids
+}
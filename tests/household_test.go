@@ -0,0 +1,170 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	dinnerservice "dinner/internal/services/dinner"
+	householdservice "dinner/internal/services/household"
+	"dinner/internal/storages"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockHouseholdStorage struct {
+	mock.Mock
+}
+
+func (m *MockHouseholdStorage) GetHousehold(userId int64) (models.Household, error) {
+	args := m.Called(userId)
+	return args.Get(0).(models.Household), args.Error(1)
+}
+func (m *MockHouseholdStorage) GetHouseholdByCode(code string) (models.Household, error) {
+	args := m.Called(code)
+	return args.Get(0).(models.Household), args.Error(1)
+}
+func (m *MockHouseholdStorage) CreateHousehold(ownerId int64, code string) (int64, error) {
+	args := m.Called(ownerId, code)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockHouseholdStorage) AddHouseholdMember(householdId int64, userId int64) error {
+	args := m.Called(householdId, userId)
+	return args.Error(0)
+}
+func (m *MockHouseholdStorage) RemoveHouseholdMember(householdId int64, userId int64) error {
+	args := m.Called(householdId, userId)
+	return args.Error(0)
+}
+func (m *MockHouseholdStorage) SetHouseholdCode(householdId int64, code string) error {
+	args := m.Called(householdId, code)
+	return args.Error(0)
+}
+func (m *MockHouseholdStorage) DeleteHousehold(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+func (m *MockHouseholdStorage) GetRestrictions(userId int64) ([]string, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]string), args.Error(1)
+}
+func (m *MockHouseholdStorage) SetRestrictions(userId int64, items []string) error {
+	args := m.Called(userId, items)
+	return args.Error(0)
+}
+
+// Семья владельца 1 с участником 2, пользователь 3 ни в какой семье не состоит
+var testHousehold = models.Household{ID: 10, OwnerID: 1, Code: "abc", Members: []models.User{{ID: 1}, {ID: 2}}}
+
+func newHouseholdStorage() *MockHouseholdStorage {
+	storage := new(MockHouseholdStorage)
+	storage.On("GetHousehold", int64(1)).Return(testHousehold, nil)
+	storage.On("GetHousehold", int64(2)).Return(testHousehold, nil)
+	storage.On("GetHousehold", int64(3)).Return(models.Household{}, storages.ErrHouseholdNotFound)
+	storage.On("GetRestrictions", int64(1)).Return([]string{"грибы"}, nil)
+	storage.On("GetRestrictions", int64(2)).Return([]string{"свинина", "грибы"}, nil)
+	storage.On("GetRestrictions", int64(3)).Return([]string{"рыба"}, nil)
+	return storage
+}
+
+func TestHouseholdAccount(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	households := householdservice.New(log, newHouseholdStorage())
+
+	assert.Equal(t, int64(1), households.Account(1))
+	assert.Equal(t, int64(1), households.Account(2), "members share the owner's account")
+	assert.Equal(t, int64(3), households.Account(3))
+
+	items, err := households.CombinedRestrictions(2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"грибы", "свинина"}, items)
+	items, err = households.CombinedRestrictions(3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"рыба"}, items)
+}
+
+func TestHouseholdMembers(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	storage := newHouseholdStorage()
+	storage.On("GetHouseholdByCode", "abc").Return(testHousehold, nil)
+	storage.On("GetHouseholdByCode", mock.Anything).Return(models.Household{}, storages.ErrHouseholdNotFound)
+	storage.On("AddHouseholdMember", int64(10), int64(4)).Return(nil)
+	storage.On("GetHousehold", int64(4)).Return(testHousehold, nil)
+	storage.On("AddHouseholdMember", int64(10), int64(2)).Return(storages.ErrAlreadyInHousehold)
+	storage.On("RemoveHouseholdMember", int64(10), mock.Anything).Return(nil)
+	storage.On("SetHouseholdCode", int64(10), mock.Anything).Return(nil)
+	storage.On("DeleteHousehold", int64(10)).Return(nil)
+	storage.On("CreateHousehold", int64(2), mock.Anything).Return(int64(0), storages.ErrAlreadyInHousehold)
+	households := householdservice.New(log, storage)
+
+	household, err := households.Join(4, " ABC ")
+	assert.NoError(t, err)
+	assert.Equal(t, testHousehold.ID, household.ID)
+	_, err = households.Join(2, "abc")
+	assert.ErrorIs(t, err, services.ErrAlreadyInHousehold)
+	_, err = households.Join(3, "missing")
+	assert.ErrorIs(t, err, services.ErrHouseholdNotFound)
+	_, err = households.Create(2)
+	assert.ErrorIs(t, err, services.ErrAlreadyInHousehold)
+
+	// Удалять участников может только владелец
+	_, err = households.Remove(2, 1)
+	assert.ErrorIs(t, err, services.ErrAccessDenied)
+	_, err = households.Remove(1, 1)
+	assert.ErrorIs(t, err, services.ErrAccessDenied)
+	storage.AssertNotCalled(t, "SetHouseholdCode", mock.Anything, mock.Anything)
+	_, err = households.Remove(1, 2)
+	assert.NoError(t, err)
+	storage.AssertCalled(t, "RemoveHouseholdMember", int64(10), int64(2))
+	// Код приглашения меняется: по старой ссылке удаленный участник не вернется
+	storage.AssertCalled(t, "SetHouseholdCode", int64(10), mock.MatchedBy(func(code string) bool {
+		return code != "" && code != testHousehold.Code
+	}))
+
+	// Участник уходит сам, владелец распускает семью
+	deleted, err := households.Leave(2)
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+	deleted, err = households.Leave(1)
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+	storage.AssertCalled(t, "DeleteHousehold", int64(10))
+	_, err = households.Leave(3)
+	assert.ErrorIs(t, err, services.ErrHouseholdNotFound)
+}
+
+func TestGetDinnerExclude(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	foods := []models.Food{
+		{ID: 1, Name: "Грибной суп", Category: models.Soup, Ingredients: []string{"грибы", "картофель"}},
+		{ID: 2, Name: "Солянка", Category: models.Soup, Tags: []string{"свинина"}},
+		{ID: 3, Name: "Уха", Category: models.Soup},
+	}
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("SavePlanRequest", mock.Anything, mock.Anything).Return(nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, dinnerservice.NewSource(1))
+
+	opts := dinnerservice.Options{Exclude: []string{"грибы", "свинина"}}
+	for range 10 {
+		dinner, err := dinnerService.GetDinner(1, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "Уха", dinner[0].Name)
+	}
+	plan, err := dinnerService.GetPlan(1, 3, opts)
+	require.NoError(t, err)
+	for _, dinner := range plan {
+		assert.Equal(t, "Уха", dinner[0].Name)
+	}
+
+	_, err = dinnerService.GetDinner(1, dinnerservice.Options{Exclude: []string{"грибы", "свинина", "уха"}})
+	assert.ErrorIs(t, err, services.ErrNoMatchingDinner)
+}
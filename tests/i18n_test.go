@@ -0,0 +1,44 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/lib/i18n"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestI18nJoin(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     i18n.Lang
+		items    []string
+		expected string
+	}{
+		{name: "empty", lang: i18n.Ru, items: nil, expected: ""},
+		{name: "one", lang: i18n.Ru, items: []string{"Борщ"}, expected: "Борщ"},
+		{name: "two ru", lang: i18n.Ru, items: []string{"Котлеты", "рис"}, expected: "Котлеты и рис"},
+		{name: "three ru", lang: i18n.Ru, items: []string{"Оливье", "борщ", "котлеты"}, expected: "Оливье, борщ и котлеты"},
+		{name: "two en", lang: i18n.En, items: []string{"Cutlets", "rice"}, expected: "Cutlets and rice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, i18n.Join(tt.lang, tt.items))
+		})
+	}
+}
+
+func TestI18nResolve(t *testing.T) {
+	assert.Equal(t, i18n.En, i18n.Resolve("", "en-US"))
+	assert.Equal(t, i18n.Ru, i18n.Resolve("ru", "en"))
+	assert.Equal(t, i18n.Default, i18n.Resolve("", "de"))
+	assert.Equal(t, i18n.En, i18n.Resolve("", "EN"))
+}
+
+func TestI18nFoodName(t *testing.T) {
+	food := models.Food{Name: "Котлеты", Names: map[string]string{"en": "Cutlets"}}
+	assert.Equal(t, "Cutlets", i18n.FoodName(i18n.En, food))
+	assert.Equal(t, "Котлеты", i18n.FoodName(i18n.Ru, food))
+	assert.Equal(t, "Котлеты", i18n.FoodName(i18n.En, models.Food{Name: "Котлеты"}))
+}
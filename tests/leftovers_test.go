@@ -0,0 +1,124 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	leftoversservice "dinner/internal/services/leftovers"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockLeftoverStorage struct {
+	mock.Mock
+}
+
+func (m *MockLeftoverStorage) GetHistory(userId int64) ([]models.HistoryEntry, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]models.HistoryEntry), args.Error(1)
+}
+func (m *MockLeftoverStorage) GetFoods() ([]models.Food, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Food), args.Error(1)
+}
+func (m *MockLeftoverStorage) GetLeftover(userId int64) (models.Leftover, error) {
+	args := m.Called(userId)
+	return args.Get(0).(models.Leftover), args.Error(1)
+}
+func (m *MockLeftoverStorage) SaveLeftover(userId int64, leftover models.Leftover) error {
+	args := m.Called(userId, leftover)
+	return args.Error(0)
+}
+func (m *MockLeftoverStorage) DeleteLeftover(userId int64) error {
+	args := m.Called(userId)
+	return args.Error(0)
+}
+
+func TestLeftoversKeep(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	now := time.Date(2025, time.January, 29, 20, 30, 0, 0, time.UTC)
+	borscht := models.Food{ID: 1, Name: "Борщ", Category: models.Soup}
+
+	storage := new(MockLeftoverStorage)
+	storage.On("GetHistory", int64(1)).Return([]models.HistoryEntry{
+		{Time: now.Add(-24 * time.Hour), Foods: []models.Food{{ID: 3, Name: "Котлеты", Category: models.Meat}}},
+		{Time: now, Foods: []models.Food{{ID: 1, Name: "Борщ", Category: models.Soup}}},
+	}, nil)
+	storage.On("GetHistory", int64(2)).Return([]models.HistoryEntry{}, nil)
+	// После ужина запрошен план: остатками становится ужин, а не блюда плана
+	storage.On("GetHistory", int64(3)).Return([]models.HistoryEntry{
+		{Time: now.Add(-time.Hour), Foods: []models.Food{borscht}},
+		{Time: now, Plan: true, Foods: []models.Food{{ID: 3, Name: "Котлеты", Category: models.Meat}, borscht}},
+	}, nil)
+	// Блюдо удалено из каталога
+	storage.On("GetHistory", int64(4)).Return([]models.HistoryEntry{
+		{Time: now, Foods: []models.Food{{ID: 3, Name: "Котлеты", Category: models.Meat}}},
+	}, nil)
+	storage.On("GetFoods").Return([]models.Food{{ID: 1, Name: "Борщ", Category: models.Soup, Names: map[string]string{"en": "Borscht"}}}, nil)
+	// Остатки на 2 дня после 29.01 заканчиваются в полночь 01.02
+	until := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+	storage.On("SaveLeftover", int64(1), models.Leftover{Foods: []models.Food{borscht}, Until: until}).Return(nil)
+	storage.On("SaveLeftover", int64(3), models.Leftover{Foods: []models.Food{borscht}, Until: until}).Return(nil)
+
+	leftovers := leftoversservice.New(log, storage)
+	leftover, err := leftovers.Keep(1, 2, now)
+	require.NoError(t, err)
+	assert.Equal(t, until, leftover.Until)
+	assert.Equal(t, "Borscht", leftover.Foods[0].Names["en"], "foods are taken from the catalog")
+
+	_, err = leftovers.Keep(1, 0, now)
+	assert.ErrorIs(t, err, services.ErrInvalidLeftoverDays)
+	_, err = leftovers.Keep(1, leftoversservice.MaxDays+1, now)
+	assert.ErrorIs(t, err, services.ErrInvalidLeftoverDays)
+	_, err = leftovers.Keep(2, 2, now)
+	assert.ErrorIs(t, err, services.ErrNoDinnerToKeep)
+	leftover, err = leftovers.Keep(3, 2, now)
+	require.NoError(t, err)
+	assert.Len(t, leftover.Foods, 1)
+	_, err = leftovers.Keep(4, 2, now)
+	assert.ErrorIs(t, err, services.ErrNoDinnerToKeep)
+	storage.AssertExpectations(t)
+}
+
+func TestLeftoversCurrent(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	until := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+	leftover := models.Leftover{Foods: []models.Food{{ID: 1, Name: "Борщ", Category: models.Soup}}, Until: until}
+
+	tests := []struct {
+		name    string
+		stored  models.Leftover
+		now     time.Time
+		ok      bool
+		deleted bool
+	}{
+		{name: "none", stored: models.Leftover{}, now: until.Add(-time.Hour)},
+		{name: "active", stored: leftover, now: until.Add(-time.Hour), ok: true},
+		{name: "expired", stored: leftover, now: until, deleted: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := new(MockLeftoverStorage)
+			storage.On("GetLeftover", int64(1)).Return(tt.stored, nil)
+			storage.On("GetFoods").Return(catalogFoods, nil)
+			storage.On("DeleteLeftover", int64(1)).Return(nil)
+
+			res, ok, err := leftoversservice.New(log, storage).Current(1, tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ok, ok)
+			if ok {
+				assert.Equal(t, tt.stored.Foods[0].ID, res.Foods[0].ID)
+			}
+			if tt.deleted {
+				storage.AssertCalled(t, "DeleteLeftover", int64(1))
+			} else {
+				storage.AssertNotCalled(t, "DeleteLeftover", mock.Anything)
+			}
+		})
+	}
+}
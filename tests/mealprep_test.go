@@ -0,0 +1,71 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	dinnerservice "dinner/internal/services/dinner"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func prepFoods() []models.Food {
+	return []models.Food{
+		{ID: 1, Name: "Курица терияки", Category: models.Meat, Ingredients: []string{"курица", "соус", "лук"}},
+		{ID: 2, Name: "Говядина", Category: models.Meat, Ingredients: []string{"говядина", "морковь"}},
+		{ID: 3, Name: "Рис", Category: models.SideDish, Ingredients: []string{"рис"}},
+		{ID: 4, Name: "Пюре", Category: models.SideDish, Ingredients: []string{"картофель", "молоко"}},
+		{ID: 5, Name: "Куриный суп", Category: models.Soup, Ingredients: []string{"курица", "лук", "морковь", "картофель"}},
+		{ID: 6, Name: "Борщ", Category: models.Soup, Ingredients: []string{"свекла", "капуста", "говядина"}},
+		{ID: 7, Name: "Овощной салат", Category: models.Salad, Ingredients: []string{"огурцы", "помидоры"}},
+	}
+}
+
+func TestGetMealPrep(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	foods := prepFoods()
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	cookOrder := []models.Food{foods[4], foods[0], foods[1], foods[2], foods[3]}
+	mockHistoryProvider.On("SavePlanRequest", int64(1), cookOrder).Return(nil)
+	mockProfileProvider := new(MockProfileProvider)
+	mockProfileProvider.On("GetRatings", int64(1)).Return(map[int64]int{}, nil)
+	mockProfileProvider.On("GetHistory", int64(1)).Return([]models.HistoryEntry{}, nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, mockProfileProvider, nil)
+
+	// Первый ужин выбирает стратегия, следующие - с наибольшим числом общих ингредиентов
+	prep, err := dinnerService.GetMealPrep(1, 3, 4, dinnerservice.Options{Mode: dinnerservice.StrategyRoundRobin})
+	require.NoError(t, err)
+	assert.Equal(t, [][]models.Food{{foods[0], foods[2]}, {foods[4]}, {foods[1], foods[3]}}, prep.Dinners)
+	assert.Equal(t, 4, prep.Portions)
+	// Суп, мясо, гарниры
+	assert.Equal(t, cookOrder, prep.CookOrder)
+	assert.Equal(t, []models.ShoppingItem{
+		{Ingredient: "картофель", Dishes: 2, Portions: 8},
+		{Ingredient: "курица", Dishes: 2, Portions: 8},
+		{Ingredient: "лук", Dishes: 2, Portions: 8},
+		{Ingredient: "морковь", Dishes: 2, Portions: 8},
+		{Ingredient: "говядина", Dishes: 1, Portions: 4},
+		{Ingredient: "молоко", Dishes: 1, Portions: 4},
+		{Ingredient: "рис", Dishes: 1, Portions: 4},
+		{Ingredient: "соус", Dishes: 1, Portions: 4},
+	}, prep.Shopping)
+	assert.Equal(t, []string{"картофель", "курица", "лук", "морковь"}, prep.Shared())
+	mockHistoryProvider.AssertExpectations(t)
+
+	for _, size := range [][2]int{{0, 4}, {dinnerservice.MaxPrepDinners + 1, 4}, {3, 0}, {3, dinnerservice.MaxPrepPortions + 1}} {
+		_, err = dinnerService.GetMealPrep(1, size[0], size[1], dinnerservice.Options{})
+		assert.ErrorIs(t, err, services.ErrInvalidMealPrep, size)
+	}
+
+	// Все блюда исключены
+	_, err = dinnerService.GetMealPrep(1, 3, 4, dinnerservice.Options{Exclude: []string{"курица", "говядина", "рис", "картофель", "огурцы"}})
+	assert.ErrorIs(t, err, services.ErrNoMatchingDinner)
+}
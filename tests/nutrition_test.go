@@ -0,0 +1,128 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	dinnerservice "dinner/internal/services/dinner"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseKcalRange(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected models.KcalRange
+		err      error
+	}{
+		{value: "400-700", expected: models.KcalRange{Min: 400, Max: 700}},
+		{value: "-600", expected: models.KcalRange{Max: 600}},
+		{value: "600", expected: models.KcalRange{Max: 600}},
+		{value: "400-", expected: models.KcalRange{Min: 400}},
+		{value: "off", expected: models.KcalRange{}},
+		{value: "700-400", err: services.ErrInvalidKcalTarget},
+		{value: "много", err: services.ErrInvalidKcalTarget},
+		{value: "0", err: services.ErrInvalidKcalTarget},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			target, err := dinnerservice.ParseKcalRange(tt.value)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, target)
+		})
+	}
+}
+
+func TestGetDinnerKcal(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	foods := []models.Food{
+		{Name: "Soup", Category: models.Soup, Nutrition: &models.Nutrition{Kcal: 250}},
+		{Name: "Salad", Category: models.Salad, Nutrition: &models.Nutrition{Kcal: 400}},
+		{Name: "Steak", Category: models.Meat, Nutrition: &models.Nutrition{Kcal: 450}},
+		{Name: "Rice", Category: models.SideDish, Nutrition: &models.Nutrition{Kcal: 230}},
+		{Name: "Unknown", Category: models.Salad},
+	}
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, nil)
+
+	tests := []struct {
+		name     string
+		opts     dinnerservice.Options
+		expected []models.Food
+		err      error
+	}{
+		{name: "light", opts: dinnerservice.Options{Light: true, Kcal: models.KcalRange{Min: 300}}, expected: []models.Food{foods[1]}},
+		{name: "range", opts: dinnerservice.Options{Kcal: models.KcalRange{Min: 600, Max: 700}}, expected: []models.Food{foods[2], foods[3]}},
+		{name: "light below target", opts: dinnerservice.Options{Light: true, Kcal: models.KcalRange{Max: 300}}, expected: []models.Food{foods[0]}},
+		{name: "nothing fits", opts: dinnerservice.Options{Kcal: models.KcalRange{Min: 1000}}, err: services.ErrNoMatchingDinner},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Единственный подходящий ужин выбирается при любом зерне
+			for seed := uint64(1); seed <= 10; seed++ {
+				dinner, err := dinnerService.WithSeed(seed).GetDinner(1, tt.opts)
+				if tt.err != nil {
+					assert.ErrorIs(t, err, tt.err)
+					continue
+				}
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expected, dinner)
+			}
+		})
+	}
+
+	total, ok := models.TotalNutrition([]models.Food{foods[2], foods[3]})
+	assert.True(t, ok)
+	assert.Equal(t, 680.0, total.Kcal)
+	_, ok = models.TotalNutrition([]models.Food{foods[4]})
+	assert.False(t, ok)
+}
+
+func TestGetDinnerKcalShares(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	// 2 супа, 2 мяса и 10 гарниров: у каждого блюда равный шанс быть первым,
+	// поэтому суп выпадает в 2 из 14 ужинов с целью по калориям и без нее
+	var foods []models.Food
+	add := func(n int, category models.FootCategory) {
+		for range n {
+			foods = append(foods, models.Food{ID: int64(len(foods) + 1), Category: category, Nutrition: &models.Nutrition{Kcal: 100}})
+		}
+	}
+	add(2, models.Soup)
+	add(2, models.Meat)
+	add(10, models.SideDish)
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, dinnerservice.NewSource(17))
+
+	const runs = 2000
+	for _, opts := range []dinnerservice.Options{{}, {Kcal: models.KcalRange{Max: 1000}}} {
+		soups := 0
+		for range runs {
+			dinner, err := dinnerService.GetDinner(1, opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+			if dinner[0].Category == models.Soup {
+				soups++
+			}
+		}
+		assert.InDelta(t, 2.0/14, float64(soups)/runs, 0.03, "kcal %v", opts.Kcal)
+	}
+}
@@ -0,0 +1,117 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	catalogservice "dinner/internal/services/catalog"
+	dinnerservice "dinner/internal/services/dinner"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPairingProvider struct {
+	mock.Mock
+}
+
+func (m *MockPairingProvider) GetPairings() ([]models.Pairing, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Pairing), args.Error(1)
+}
+
+var pairingFoods = []models.Food{
+	{ID: 1, Name: "Сосиски", Category: models.Meat},
+	{ID: 2, Name: "Тушеные овощи", Category: models.SideDish},
+	{ID: 3, Name: "Макароны", Category: models.SideDish},
+	{ID: 4, Name: "Рис", Category: models.SideDish},
+	{ID: 5, Name: "Борщ", Category: models.Soup},
+}
+
+var pairingTable = []models.Pairing{
+	{MeatID: 1, SideID: 2, Weight: models.PairForbidden},
+	{MeatID: 1, SideID: 3, Weight: models.PairPreferred},
+}
+
+func TestPairingsWeight(t *testing.T) {
+	pairings := models.NewPairings(pairingTable)
+	assert.Equal(t, models.PairForbidden, pairings.Weight(pairingFoods[0], pairingFoods[1]))
+	assert.Equal(t, models.PairPreferred, pairings.Weight(pairingFoods[2], pairingFoods[0]))
+	assert.Equal(t, models.PairAllowed, pairings.Weight(pairingFoods[0], pairingFoods[3]))
+	assert.Equal(t, models.PairAllowed, pairings.Weight(pairingFoods[4], pairingFoods[1]))
+
+	for value, expected := range map[string]int{"forbid": 0, "allow": 1, "Prefer": 3, "5": 5} {
+		weight, ok := models.ParsePairWeight(value)
+		assert.True(t, ok, value)
+		assert.Equal(t, expected, weight, value)
+	}
+	for _, value := range []string{"", "-1", "11", "often"} {
+		_, ok := models.ParsePairWeight(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestGetDinnerPairing(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(pairingFoods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	mockPairingProvider := new(MockPairingProvider)
+	mockPairingProvider.On("GetPairings").Return(pairingTable, nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, mockPairingProvider, nil, nil)
+
+	counts := map[string]int{}
+	for seed := uint64(1); seed <= 300; seed++ {
+		dinner, err := dinnerService.WithSeed(seed).GetRandomDinner(1)
+		assert.Nil(t, err)
+		if len(dinner) == 2 {
+			counts[dinner[0].Name+" + "+dinner[1].Name]++
+		}
+	}
+	// Запрещенное сочетание не предлагается ни от мяса, ни от гарнира
+	assert.Zero(t, counts["Сосиски + Тушеные овощи"])
+	assert.Zero(t, counts["Тушеные овощи + Сосиски"])
+	// Удачное сочетание предлагается чаще обычного
+	assert.Greater(t, counts["Сосиски + Макароны"], counts["Сосиски + Рис"])
+
+	// Если все гарниры запрещены, мясо предлагается без гарнира
+	allForbidden := new(MockPairingProvider)
+	allForbidden.On("GetPairings").Return([]models.Pairing{
+		{MeatID: 1, SideID: 2}, {MeatID: 1, SideID: 3}, {MeatID: 1, SideID: 4},
+	}, nil)
+	meatOnly := new(MockFoodProvider)
+	meatOnly.On("GetFoods").Return(pairingFoods[:4], nil)
+	dinnerService = dinnerservice.New(log, meatOnly, mockHistoryProvider, allForbidden, nil, nil)
+	for seed := uint64(1); seed <= 30; seed++ {
+		dinner, err := dinnerService.WithSeed(seed).GetRandomDinner(1)
+		assert.Nil(t, err)
+		assert.Len(t, dinner, 1)
+	}
+}
+
+func TestCatalogSetPairing(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	storage := new(MockCatalogStorage)
+	storage.On("GetFoods").Return(pairingFoods, nil)
+	storage.On("SetPairing", mock.Anything).Return(nil)
+	catalog := catalogservice.New(log, storage)
+
+	// Гарнир и мясо в обратном порядке
+	pairing, err := catalog.SetPairing(models.Pairing{MeatID: 3, SideID: 1, Weight: models.PairPreferred})
+	assert.Nil(t, err)
+	assert.Equal(t, models.Pairing{MeatID: 1, SideID: 3, Weight: models.PairPreferred}, pairing)
+	storage.AssertCalled(t, "SetPairing", pairing)
+
+	_, err = catalog.SetPairing(models.Pairing{MeatID: 1, SideID: 5, Weight: models.PairPreferred})
+	assert.ErrorIs(t, err, services.ErrInvalidPairing)
+	_, err = catalog.SetPairing(models.Pairing{MeatID: 1, SideID: 42, Weight: models.PairPreferred})
+	assert.ErrorIs(t, err, services.ErrFoodNotFound)
+	_, err = catalog.SetPairing(models.Pairing{MeatID: 1, SideID: 3, Weight: models.MaxPairWeight + 1})
+	assert.ErrorIs(t, err, services.ErrInvalidPairing)
+}
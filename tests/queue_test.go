@@ -0,0 +1,149 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	dinnerservice "dinner/internal/services/dinner"
+	"log/slog"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryQueue хранит очередь блюд в памяти, оценок и истории нет
+type memoryQueue struct {
+	queue models.FoodQueue
+}
+
+func (m *memoryQueue) GetHistory(int64) ([]models.HistoryEntry, error) { return nil, nil }
+func (m *memoryQueue) GetRatings(int64) (map[int64]int, error)         { return nil, nil }
+func (m *memoryQueue) SetRating(int64, int64, int) error               { return nil }
+func (m *memoryQueue) GetQueue(int64) (models.FoodQueue, error) {
+	return slices.Clone(m.queue), nil
+}
+func (m *memoryQueue) SaveQueue(_ int64, queue models.FoodQueue) error {
+	m.queue = slices.Clone(queue)
+	return nil
+}
+
+// queueFoods - каталог без мяса и гарниров, чтобы ужин был из одного блюда
+func queueFoods(ids ...int64) []models.Food {
+	foods := make([]models.Food, 0, len(ids))
+	for _, id := range ids {
+		foods = append(foods, models.Food{ID: id, Name: "Soup " + string(rune('A'+id)), Category: models.Soup})
+	}
+	return foods
+}
+
+// shuffleDinners подбирает n ужинов в режиме shuffle и отдает id блюд
+func shuffleDinners(t *testing.T, foods []models.Food, queue *memoryQueue, n int) []int64 {
+	t.Helper()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, queue, dinnerservice.NewSource(5))
+
+	ids := make([]int64, 0, n)
+	for range n {
+		dinner, err := dinnerService.GetDinner(1, dinnerservice.Options{Mode: dinnerservice.StrategyShuffle})
+		require.NoError(t, err)
+		require.Len(t, dinner, 1)
+		ids = append(ids, dinner[0].ID)
+	}
+	return ids
+}
+
+func TestShuffleCycle(t *testing.T) {
+	queue := &memoryQueue{}
+	ids := shuffleDinners(t, queueFoods(1, 2, 3, 4), queue, 8)
+
+	// Каждый круг - все блюда по разу
+	first, second := slices.Clone(ids[:4]), slices.Clone(ids[4:])
+	slices.Sort(first)
+	slices.Sort(second)
+	assert.Equal(t, []int64{1, 2, 3, 4}, first)
+	assert.Equal(t, []int64{1, 2, 3, 4}, second)
+}
+
+func TestShuffleCatalogChanges(t *testing.T) {
+	queue := &memoryQueue{}
+	served := shuffleDinners(t, queueFoods(1, 2, 3, 4), queue, 2)
+
+	// Блюдо 5 добавлено, а одно из еще не предложенных удалено посреди круга
+	var removed int64
+	for _, id := range []int64{1, 2, 3, 4} {
+		if !slices.Contains(served, id) {
+			removed = id
+			break
+		}
+	}
+	catalog := []int64{5}
+	for _, id := range []int64{1, 2, 3, 4} {
+		if id != removed {
+			catalog = append(catalog, id)
+		}
+	}
+	rest := shuffleDinners(t, queueFoods(catalog...), queue, 2)
+
+	// До конца круга - оставшееся блюдо и новое, без повторов и без удаленного
+	assert.NotContains(t, rest, removed)
+	assert.Contains(t, rest, int64(5))
+	for _, id := range rest {
+		assert.NotContains(t, served, id)
+	}
+
+	// Следующий круг - весь новый каталог
+	next := shuffleDinners(t, queueFoods(catalog...), queue, 4)
+	slices.Sort(next)
+	slices.Sort(catalog)
+	assert.Equal(t, catalog, next)
+}
+
+func TestStrategyShuffle(t *testing.T) {
+	options := strategyOptions(strategyBorscht, strategyCaesar, strategyShchi)
+	profile := dinnerservice.Profile{Queue: models.FoodQueue{
+		{FoodID: 3, Served: true},
+		{FoodID: 2},
+		{FoodID: 1},
+	}}
+	choice := dinnerservice.Shuffle{}.Choose(options, profile, fixedIntN(0))
+	assert.Equal(t, 1, choice.Index)
+
+	// Первое в очереди блюдо сейчас недоступно - следующее
+	options[1].Weight = 0
+	choice = dinnerservice.Shuffle{}.Choose(options, profile, fixedIntN(0))
+	assert.Equal(t, 0, choice.Index)
+
+	// Из очереди ничего не подходит - случайный выбор
+	options[0].Weight = 0
+	choice = dinnerservice.Shuffle{}.Choose(options, profile, fixedIntN(0))
+	assert.Equal(t, 2, choice.Index)
+}
+
+func TestShuffleDinnerWith(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(queueFoods(1, 2, 3), nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	queue := &memoryQueue{}
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, queue, dinnerservice.NewSource(5))
+
+	// Блюдо, выбранное через /find, отмечается в очереди, и до конца круга не повторяется
+	opts := dinnerservice.Options{Mode: dinnerservice.StrategyShuffle}
+	dinner, _, err := dinnerService.GetDinnerWith(1, 2, opts)
+	require.NoError(t, err)
+	require.Len(t, dinner, 1)
+	for range 2 {
+		dinner, err := dinnerService.GetDinner(1, opts)
+		require.NoError(t, err)
+		assert.NotEqual(t, int64(2), dinner[0].ID)
+	}
+}
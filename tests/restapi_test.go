@@ -0,0 +1,157 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	restapi "dinner/internal/restApi"
+	adminservice "dinner/internal/services/admin"
+	catalogservice "dinner/internal/services/catalog"
+	dinnerservice "dinner/internal/services/dinner"
+	householdservice "dinner/internal/services/household"
+	leftoversservice "dinner/internal/services/leftovers"
+	preferencesservice "dinner/internal/services/preferences"
+	statsservice "dinner/internal/services/stats"
+	"dinner/internal/storages"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStatsProvider struct {
+	mock.Mock
+}
+
+func (m *MockStatsProvider) GetHistory(userId int64) ([]models.HistoryEntry, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]models.HistoryEntry), args.Error(1)
+}
+func (m *MockStatsProvider) GetCategories() ([]models.Category, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Category), args.Error(1)
+}
+
+const (
+	testUserKey  = "user-key"
+	testAdminKey = "admin-key"
+)
+
+func newTestAPI(limit bool) http.Handler {
+	return newTestAPIWith(limit, newTestUserProvider())
+}
+
+// newTestUserProvider - пользователи без настроек, журнал действий администраторов пишется
+func newTestUserProvider() *MockUserProvider {
+	userProvider := new(MockUserProvider)
+	userProvider.On("IsBanned", mock.Anything).Return(false, nil)
+	userProvider.On("GetUserLang", mock.Anything).Return("", nil)
+	userProvider.On("GetKcalTarget", mock.Anything).Return(models.KcalRange{}, nil)
+	userProvider.On("GetTimezone", mock.Anything).Return("", nil)
+	userProvider.On("GetMode", mock.Anything).Return("", nil)
+	userProvider.On("GetBudget", mock.Anything).Return(0, nil)
+	userProvider.On("SaveAudit", mock.Anything).Return(nil)
+	return userProvider
+}
+
+func newTestAPIWith(limit bool, userProvider *MockUserProvider) http.Handler {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	foodProvider := new(MockFoodProvider)
+	foodProvider.On("GetFoods").Return(catalogFoods, nil)
+	historyProvider := new(MockHistoryProvider)
+	historyProvider.On("IsLimit", mock.Anything).Return(limit, nil)
+	historyProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	historyProvider.On("SavePlanRequest", mock.Anything, mock.Anything).Return(nil)
+
+	catalogStorage := new(MockCatalogStorage)
+	catalogStorage.On("GetFoods").Return(catalogFoods, nil)
+	catalogStorage.On("GetCategories").Return(catalogCategories, nil)
+	catalogStorage.On("GetIngredientPrices").Return(models.Prices{}, nil)
+	catalogStorage.On("CreateFood", mock.Anything).Return(int64(0), storages.ErrFoodExists)
+
+	statsProvider := new(MockStatsProvider)
+	statsProvider.On("GetHistory", mock.Anything).Return([]models.HistoryEntry{}, nil)
+
+	leftoverStorage := new(MockLeftoverStorage)
+	leftoverStorage.On("GetLeftover", mock.Anything).Return(models.Leftover{}, nil)
+	leftoverStorage.On("GetHistory", mock.Anything).Return([]models.HistoryEntry{}, nil)
+
+	api := restapi.New(
+		log,
+		"",
+		map[string]int64{testUserKey: 1, testAdminKey: testAdminId},
+		dinnerservice.New(log, foodProvider, historyProvider, nil, nil, nil),
+		catalogservice.New(log, catalogStorage),
+		statsservice.New(log, statsProvider),
+		adminservice.New(log, []int64{testAdminId}, userProvider),
+		preferencesservice.New(log, userProvider),
+		leftoversservice.New(log, leftoverStorage),
+		householdservice.New(log, newHouseholdStorage()),
+	)
+	return api.Handler()
+}
+
+func TestRestAPI(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		key    string
+		body   string
+		limit  bool
+		status int
+	}{
+		{name: "no key", method: http.MethodGet, path: "/api/v1/dinner", limit: true, status: http.StatusUnauthorized},
+		{name: "dinner", method: http.MethodGet, path: "/api/v1/dinner", key: testUserKey, limit: true, status: http.StatusOK},
+		{name: "dinner limit", method: http.MethodGet, path: "/api/v1/dinner", key: testUserKey, status: http.StatusTooManyRequests},
+		{name: "plan", method: http.MethodGet, path: "/api/v1/plan?days=3", key: testUserKey, limit: true, status: http.StatusOK},
+		{name: "plan too long", method: http.MethodGet, path: "/api/v1/plan?days=30", key: testUserKey, limit: true, status: http.StatusBadRequest},
+		{name: "history", method: http.MethodGet, path: "/api/v1/history", key: testUserKey, status: http.StatusOK},
+		{name: "food not found", method: http.MethodGet, path: "/api/v1/foods/999", key: testUserKey, status: http.StatusNotFound},
+		{name: "create by user", method: http.MethodPost, path: "/api/v1/foods", key: testUserKey, body: `{"name":"Плов","category":3}`, status: http.StatusForbidden},
+		{name: "create invalid", method: http.MethodPost, path: "/api/v1/foods", key: testAdminKey, body: `{"name":"","category":3}`, status: http.StatusBadRequest},
+		{name: "create existing", method: http.MethodPost, path: "/api/v1/foods", key: testAdminKey, body: `{"name":"Плов","category":3}`, status: http.StatusConflict},
+		{name: "no leftovers", method: http.MethodGet, path: "/api/v1/leftovers", key: testUserKey, status: http.StatusNotFound},
+		{name: "leftovers without dinner", method: http.MethodPost, path: "/api/v1/leftovers", key: testUserKey, body: `{"days":2}`, status: http.StatusConflict},
+		{name: "leftovers too long", method: http.MethodPost, path: "/api/v1/leftovers", key: testUserKey, body: `{"days":30}`, status: http.StatusBadRequest},
+		{name: "openapi", method: http.MethodGet, path: "/api/openapi.yaml", status: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.key != "" {
+				req.Header.Set(restapi.APIKeyHeader, tt.key)
+			}
+			rec := httptest.NewRecorder()
+			newTestAPI(tt.limit).ServeHTTP(rec, req)
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestRestAPIAudit(t *testing.T) {
+	userProvider := newTestUserProvider()
+	api := newTestAPIWith(true, userProvider)
+
+	// Изменение каталога через API пишется в журнал так же, как в боте, даже если не удалось
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/foods", strings.NewReader(`{"name":"Плов","category":3}`))
+	req.Header.Set(restapi.APIKeyHeader, testAdminKey)
+	rec := httptest.NewRecorder()
+	api.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	userProvider.AssertCalled(t, "SaveAudit", mock.MatchedBy(func(entry models.AuditEntry) bool {
+		return entry.AdminID == testAdminId && entry.Action == adminservice.ActionFoodCreate && entry.Args == "Плов"
+	}))
+
+	// Без прав в журнал ничего не пишется
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/foods/1", nil)
+	req.Header.Set(restapi.APIKeyHeader, testUserKey)
+	rec = httptest.NewRecorder()
+	api.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	userProvider.AssertNumberOfCalls(t, "SaveAudit", 1)
+}
@@ -0,0 +1,104 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	catalogservice "dinner/internal/services/catalog"
+	dinnerservice "dinner/internal/services/dinner"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func searchFoods() []models.Food {
+	return []models.Food{
+		{ID: 1, Name: "Жареная курица", Category: models.Meat},
+		{ID: 2, Name: "Курица", Category: models.Meat, Names: map[string]string{"en": "Chicken"}},
+		{ID: 3, Name: "Котлеты из курицы", Category: models.Meat},
+		{ID: 4, Name: "Цезарь", Category: models.Salad, Ingredients: []string{"курица", "салат"}},
+		{ID: 5, Name: "Щи", Category: models.Soup, Ingredients: []string{"капуста"}},
+		{ID: 6, Name: "Рис", Category: models.SideDish},
+		{ID: 7, Name: "Гречка", Category: models.SideDish},
+	}
+}
+
+func TestCatalogSearch(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	storage := new(MockCatalogStorage)
+	storage.On("GetFoods").Return(searchFoods(), nil)
+	catalog := catalogservice.New(log, storage)
+
+	ids := func(foods []models.Food) []int64 {
+		res := make([]int64, 0, len(foods))
+		for _, food := range foods {
+			res = append(res, food.ID)
+		}
+		return res
+	}
+	tests := []struct {
+		name  string
+		query string
+		want  []int64
+	}{
+		// Точное совпадение, начало названия, подстрока, слово с другим окончанием, ингредиент
+		{name: "exact first", query: "КУРИЦА", want: []int64{2, 1, 3, 4}},
+		{name: "word form", query: "курицу", want: []int64{1, 3, 2, 4}},
+		{name: "typo", query: "курца", want: []int64{1, 3, 2, 4}},
+		{name: "translation", query: "chick", want: []int64{2}},
+		{name: "ingredient", query: "капусту", want: []int64{5}},
+		{name: "yo", query: "гречка", want: []int64{7}},
+		{name: "nothing", query: "пицца", want: []int64{}},
+		{name: "empty", query: " ", want: []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			foods, err := catalog.Search(tt.query, 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ids(foods))
+		})
+	}
+
+	foods, err := catalog.Search("курица", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 1}, ids(foods))
+}
+
+func TestGetDinnerWith(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	foods := searchFoods()
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	// Курица с рисом запрещена
+	pairings := new(MockPairingProvider)
+	pairings.On("GetPairings").Return([]models.Pairing{{MeatID: 2, SideID: 6, Weight: models.PairForbidden}}, nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, pairings, nil, nil)
+
+	for range 10 {
+		dinner, trace, err := dinnerService.GetDinnerWith(1, 2, dinnerservice.Options{})
+		require.NoError(t, err)
+		assert.Equal(t, []models.Food{foods[1], foods[6]}, dinner)
+		require.Len(t, trace.Draws, 1)
+		assert.Equal(t, models.DrawPair, trace.Draws[0].Name)
+	}
+
+	// К гарниру подбирается мясо без исключенных блюд
+	dinner, _, err := dinnerService.GetDinnerWith(1, 6, dinnerservice.Options{Exclude: []string{"котлеты из курицы"}})
+	require.NoError(t, err)
+	assert.Equal(t, []models.Food{foods[5], foods[0]}, dinner)
+
+	// Суп остается один
+	dinner, _, err = dinnerService.GetDinnerWith(1, 5, dinnerservice.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []models.Food{foods[4]}, dinner)
+
+	_, _, err = dinnerService.GetDinnerWith(1, 100, dinnerservice.Options{})
+	assert.ErrorIs(t, err, services.ErrFoodNotFound)
+	mockHistoryProvider.AssertNumberOfCalls(t, "SaveRequest", 12)
+}
@@ -0,0 +1,108 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	seedservice "dinner/internal/services/seed"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeSeed(t *testing.T, name, data string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSeedLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		data     string
+		expected models.Seed
+		err      error
+	}{
+		{
+			name: "yaml",
+			file: "foods.yaml",
+			data: "categories:\n  - id: 1\n    name: Суп\nfoods:\n  - name: Борщ\n    category: Суп\n",
+			expected: models.Seed{
+				Categories: []models.Category{{ID: models.Soup, Name: "Суп"}},
+				Foods:      []models.Food{{Name: "Борщ", Category: models.Soup}},
+			},
+		},
+		{
+			name: "yaml with ingredients",
+			file: "foods.yaml",
+			data: "categories:\n  - id: 1\n    name: Суп\nfoods:\n  - name: Борщ\n    category: Суп\n    ingredients: [Свекла, капуста, свекла]\n",
+			expected: models.Seed{
+				Categories: []models.Category{{ID: models.Soup, Name: "Суп"}},
+				Foods:      []models.Food{{Name: "Борщ", Category: models.Soup, Ingredients: []string{"капуста", "свекла"}}},
+			},
+		},
+		{
+			name: "json",
+			file: "foods.json",
+			data: `{"categories":[{"id":3,"name":"Мясо"}],"foods":[{"name":"Котлеты","category":"Мясо"}]}`,
+			expected: models.Seed{
+				Categories: []models.Category{{ID: models.Meat, Name: "Мясо"}},
+				Foods:      []models.Food{{Name: "Котлеты", Category: models.Meat}},
+			},
+		},
+		{
+			name: "pairings",
+			file: "foods.json",
+			data: `{"categories":[{"id":3,"name":"Мясо"},{"id":4,"name":"Гарнир"}],
+				"foods":[{"name":"Сосиски","category":"Мясо"},{"name":"Рис","category":"Гарнир"}],
+				"pairings":[{"meat":"Сосиски","side":"Рис","weight":"forbid"}]}`,
+			expected: models.Seed{
+				Categories: []models.Category{{ID: models.Meat, Name: "Мясо"}, {ID: models.SideDish, Name: "Гарнир"}},
+				Foods:      []models.Food{{Name: "Сосиски", Category: models.Meat}, {Name: "Рис", Category: models.SideDish}},
+				Pairings:   []models.SeedPairing{{Meat: "Сосиски", Side: "Рис", Weight: models.PairForbidden}},
+			},
+		},
+		{
+			name: "pairing of two meats",
+			file: "foods.json",
+			data: `{"categories":[{"id":3,"name":"Мясо"}],
+				"foods":[{"name":"Сосиски","category":"Мясо"},{"name":"Котлеты","category":"Мясо"}],
+				"pairings":[{"meat":"Сосиски","side":"Котлеты","weight":"prefer"}]}`,
+			err: services.ErrInvalidSeed,
+		},
+		{
+			name: "unknown category",
+			file: "foods.yaml",
+			data: "categories:\n  - id: 1\n    name: Суп\nfoods:\n  - name: Котлеты\n    category: Мясо\n",
+			err:  services.ErrInvalidSeed,
+		},
+		{
+			name: "duplicate food",
+			file: "foods.yaml",
+			data: "categories:\n  - id: 1\n    name: Суп\nfoods:\n  - name: Борщ\n    category: Суп\n  - name: Борщ\n    category: Суп\n",
+			err:  services.ErrInvalidSeed,
+		},
+		{
+			name: "unknown format",
+			file: "foods.txt",
+			data: "",
+			err:  services.ErrUnknownFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seed, err := seedservice.Load(writeSeed(t, tt.file, tt.data))
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, seed)
+		})
+	}
+}
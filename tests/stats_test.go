@@ -0,0 +1,58 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	statsservice "dinner/internal/services/stats"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsCalculate(t *testing.T) {
+	borsch := models.Food{ID: 1, Name: "Борщ", Category: models.Soup}
+	cutlet := models.Food{ID: 2, Name: "Котлеты", Category: models.Meat}
+	rice := models.Food{ID: 3, Name: "Рис", Category: models.SideDish}
+	categories := []models.Category{
+		{ID: models.Soup, Name: "Суп"},
+		{ID: models.Meat, Name: "Мясо"},
+		{ID: models.SideDish, Name: "Гарнир"},
+	}
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 19, 0, 0, 0, time.UTC)
+	}
+	history := []models.HistoryEntry{
+		{Time: day(1, 31), Foods: []models.Food{borsch}},
+		{Time: day(2, 1), Foods: []models.Food{cutlet, rice}},
+		{Time: day(2, 2), Foods: []models.Food{borsch}},
+		{Time: day(2, 2), Foods: []models.Food{borsch}},
+		{Time: day(2, 5), Foods: []models.Food{cutlet, rice}},
+		{Time: day(2, 6), Foods: []models.Food{borsch}},
+	}
+
+	stats := statsservice.Calculate(history, categories, day(2, 7))
+
+	assert.Equal(t, 6, stats.TotalRequests)
+	assert.Equal(t, 5, stats.MonthRequests)
+	assert.Equal(t, 2, stats.CurrentStreak)
+	assert.Equal(t, 3, stats.LongestStreak)
+	assert.Equal(t, []models.FoodCount{{Food: borsch, Count: 4}, {Food: cutlet, Count: 2}, {Food: rice, Count: 2}}, stats.TopFoods)
+	assert.Equal(t, []models.FoodCount{{Food: rice, Count: 2}, {Food: cutlet, Count: 2}, {Food: borsch, Count: 4}}, stats.BottomFoods)
+	assert.Equal(t, []models.CategoryCount{
+		{Category: categories[0], Count: 4},
+		{Category: categories[1], Count: 2},
+		{Category: categories[2], Count: 2},
+	}, stats.Categories)
+}
+
+func TestStatsCalculateBrokenStreak(t *testing.T) {
+	history := []models.HistoryEntry{
+		{Time: time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)},
+		{Time: time.Date(2025, 2, 2, 12, 0, 0, 0, time.UTC)},
+	}
+
+	stats := statsservice.Calculate(history, nil, time.Date(2025, 2, 4, 12, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 0, stats.CurrentStreak)
+	assert.Equal(t, 2, stats.LongestStreak)
+}
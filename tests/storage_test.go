@@ -0,0 +1,186 @@
+package dinner
+
+import (
+	"database/sql"
+	"dinner/internal/domain/models"
+	"dinner/internal/storages"
+	storagesqlite "dinner/internal/storages/sqlite"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStorage создает БД со всеми миграциями во временной папке теста
+func newTestStorage(t *testing.T) (*storagesqlite.Storage, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dinner.db")
+	m, err := migrate.New("file://../migrations", fmt.Sprintf("sqlite3://%s?x-migrations-table=migrations", path))
+	require.NoError(t, err)
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		require.NoError(t, err)
+	}
+	srcErr, dbErr := m.Close()
+	require.NoError(t, srcErr)
+	require.NoError(t, dbErr)
+
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	storage, err := storagesqlite.New(log, path)
+	require.NoError(t, err)
+	return storage, path
+}
+
+func TestDeleteFoodCascade(t *testing.T) {
+	storage, path := newTestStorage(t)
+
+	meatId, err := storage.CreateFood(models.Food{
+		Name:        "Тестовые котлеты",
+		Category:    models.Meat,
+		Tags:        []string{"мясо"},
+		Ingredients: []string{"фарш", "лук"},
+		Names:       map[string]string{"en": "Cutlets"},
+		Nutrition:   &models.Nutrition{Kcal: 300},
+		Seasons:     []models.Season{models.Winter},
+		Weekdays:    []time.Weekday{time.Monday},
+	})
+	require.NoError(t, err)
+	sideId, err := storage.CreateFood(models.Food{Name: "Тестовое пюре", Category: models.SideDish})
+	require.NoError(t, err)
+	require.NoError(t, storage.SetPairing(models.Pairing{MeatID: meatId, SideID: sideId, Weight: 3}))
+	require.NoError(t, storage.SetRating(1, meatId, 1))
+	require.NoError(t, storage.SaveQueue(1, models.FoodQueue{{FoodID: meatId}, {FoodID: sideId}}))
+	require.NoError(t, storage.SaveLeftover(1, models.Leftover{
+		Foods: []models.Food{{ID: meatId}, {ID: sideId}},
+		Until: time.Now().Add(24 * time.Hour),
+	}))
+
+	require.NoError(t, storage.DeleteFood(meatId))
+	assert.ErrorIs(t, storage.DeleteFood(meatId), storages.ErrFoodNotFound)
+
+	db, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	defer db.Close()
+	for _, query := range []string{
+		"SELECT COUNT(*) FROM food_tags WHERE foodId=?",
+		"SELECT COUNT(*) FROM food_ingredients WHERE foodId=?",
+		"SELECT COUNT(*) FROM food_names WHERE foodId=?",
+		"SELECT COUNT(*) FROM food_nutrition WHERE foodId=?",
+		"SELECT COUNT(*) FROM food_seasons WHERE foodId=?",
+		"SELECT COUNT(*) FROM food_weekdays WHERE foodId=?",
+		"SELECT COUNT(*) FROM food_pairings WHERE meatId=?1 OR sideId=?1",
+		"SELECT COUNT(*) FROM food_ratings WHERE foodId=?",
+		"SELECT COUNT(*) FROM food_queue WHERE foodId=?",
+		"SELECT COUNT(*) FROM leftovers WHERE foodId=?",
+	} {
+		var count int
+		require.NoError(t, db.QueryRow(query, meatId).Scan(&count), query)
+		assert.Zero(t, count, query)
+	}
+
+	// Строки других блюд остаются
+	queue, err := storage.GetQueue(1)
+	require.NoError(t, err)
+	assert.Equal(t, models.FoodQueue{{FoodID: sideId}}, queue)
+	leftover, err := storage.GetLeftover(1)
+	require.NoError(t, err)
+	require.Len(t, leftover.Foods, 1)
+	assert.Equal(t, sideId, leftover.Foods[0].ID)
+}
+
+func TestDeleteFoodKeepsHistory(t *testing.T) {
+	storage, _ := newTestStorage(t)
+
+	food := models.Food{Name: "Тестовый суп", Category: models.Soup}
+	id, err := storage.CreateFood(food)
+	require.NoError(t, err)
+	food.ID = id
+	require.NoError(t, storage.SaveRequest(1, []models.Food{food}))
+	require.NoError(t, storage.DeleteFood(id))
+
+	history, err := storage.GetHistory(1)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, []models.Food{food}, history[0].Foods)
+}
+
+func TestHistoryPlan(t *testing.T) {
+	storage, _ := newTestStorage(t)
+
+	foods, err := storage.GetFoods()
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveRequest(1, foods[:1]))
+	require.NoError(t, storage.SavePlanRequest(1, foods[:3]))
+
+	history, err := storage.GetHistory(1)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.False(t, history[0].Plan)
+	assert.True(t, history[1].Plan)
+	assert.Len(t, history[1].Foods, 3)
+}
+
+func TestSuggestionsRequestLimit(t *testing.T) {
+	storage, _ := newTestStorage(t)
+
+	// Запрос без блюд (варианты на выбор) расходует лимит так же, как ужин
+	for range 10 {
+		limit, err := storage.IsLimit(1)
+		require.NoError(t, err)
+		require.True(t, limit)
+		require.NoError(t, storage.SavePlanRequest(1, nil))
+	}
+	limit, err := storage.IsLimit(1)
+	require.NoError(t, err)
+	assert.False(t, limit)
+
+	history, err := storage.GetHistory(1)
+	require.NoError(t, err)
+	require.Len(t, history, 10)
+	assert.True(t, history[0].Plan)
+	assert.Empty(t, history[0].Foods)
+}
+
+func TestApplySeedReport(t *testing.T) {
+	storage, _ := newTestStorage(t)
+
+	seed := models.Seed{
+		Foods: []models.Food{
+			{Name: "Тестовые тефтели", Category: models.Meat, Ingredients: []string{"Фарш", " рис", "фарш"}},
+			{Name: "Тестовая гречка", Category: models.SideDish},
+		},
+		Pairings: []models.SeedPairing{{Meat: "Тестовые тефтели", Side: "Тестовая гречка", Weight: models.PairPreferred}},
+	}
+	report, err := storage.ApplySeed(seed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"food Тестовые тефтели", "food Тестовая гречка", "pairing Тестовые тефтели + Тестовая гречка"}, report.Added)
+	assert.Empty(t, report.Updated)
+
+	// Ингредиенты хранятся так же, как после импорта каталога
+	foods, err := storage.GetFoods()
+	require.NoError(t, err)
+	i := slices.IndexFunc(foods, func(food models.Food) bool { return food.Name == "Тестовые тефтели" })
+	require.GreaterOrEqual(t, i, 0)
+	assert.Equal(t, []string{"рис", "фарш"}, foods[i].Ingredients)
+
+	// Повторное применение ничего не меняет
+	report, err = storage.ApplySeed(seed)
+	require.NoError(t, err)
+	assert.Empty(t, report.Added)
+	assert.Empty(t, report.Updated)
+	assert.Equal(t, 3, report.Unchanged)
+
+	seed.Pairings[0].Weight = models.PairForbidden
+	report, err = storage.ApplySeed(seed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pairing Тестовые тефтели + Тестовая гречка"}, report.Updated)
+}
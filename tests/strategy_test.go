@@ -0,0 +1,212 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	dinnerservice "dinner/internal/services/dinner"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockProfileProvider struct {
+	mock.Mock
+}
+
+func (m *MockProfileProvider) GetHistory(userId int64) ([]models.HistoryEntry, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]models.HistoryEntry), args.Error(1)
+}
+func (m *MockProfileProvider) GetRatings(userId int64) (map[int64]int, error) {
+	args := m.Called(userId)
+	return args.Get(0).(map[int64]int), args.Error(1)
+}
+func (m *MockProfileProvider) SetRating(userId int64, foodId int64, rating int) error {
+	args := m.Called(userId, foodId, rating)
+	return args.Error(0)
+}
+func (m *MockProfileProvider) GetQueue(userId int64) (models.FoodQueue, error) {
+	args := m.Called(userId)
+	return args.Get(0).(models.FoodQueue), args.Error(1)
+}
+func (m *MockProfileProvider) SaveQueue(userId int64, queue models.FoodQueue) error {
+	args := m.Called(userId, queue)
+	return args.Error(0)
+}
+
+// strategyOptions - по варианту на блюдо с весом 1
+func strategyOptions(foods ...models.Food) []dinnerservice.Option {
+	options := make([]dinnerservice.Option, 0, len(foods))
+	for _, food := range foods {
+		options = append(options, dinnerservice.Option{Foods: []models.Food{food}, Weight: 1})
+	}
+	return options
+}
+
+// fixedIntN отдает источник, который всегда выдает roll (но не больше n-1)
+func fixedIntN(roll int) func(n int) int {
+	return func(n int) int { return min(roll, n-1) }
+}
+
+var (
+	strategyBorscht = models.Food{ID: 1, Name: "Borscht", Category: models.Soup}
+	strategyCaesar  = models.Food{ID: 2, Name: "Caesar", Category: models.Salad}
+	strategyShchi   = models.Food{ID: 3, Name: "Shchi", Category: models.Soup}
+)
+
+func TestStrategyByName(t *testing.T) {
+	for _, name := range dinnerservice.StrategyNames() {
+		strategy, ok := dinnerservice.StrategyByName(name)
+		require.True(t, ok, name)
+		assert.Equal(t, name, strategy.Name())
+	}
+	strategy, ok := dinnerservice.StrategyByName("")
+	require.True(t, ok)
+	assert.Equal(t, dinnerservice.StrategyRandom, strategy.Name())
+	_, ok = dinnerservice.StrategyByName("best")
+	assert.False(t, ok)
+}
+
+func TestStrategyRandom(t *testing.T) {
+	options := strategyOptions(strategyBorscht, strategyCaesar, strategyShchi)
+	options[1].Weight = 2
+
+	// Веса 1, 2, 1: выпавшие 1 и 2 приходятся на второй вариант
+	for roll, want := range []int{0, 1, 1, 2} {
+		choice := dinnerservice.Random{}.Choose(options, dinnerservice.Profile{}, fixedIntN(roll))
+		assert.Equal(t, want, choice.Index, "roll %d", roll)
+		assert.Equal(t, 4, choice.Total)
+		assert.Equal(t, roll, choice.Roll)
+	}
+	choice := dinnerservice.Random{}.Choose(options, dinnerservice.Profile{}, fixedIntN(1))
+	assert.Equal(t, 2, choice.Weight)
+
+	// Одно блюдо среди вариантов дважды: шанс складывается
+	twice := strategyOptions(strategyBorscht, strategyCaesar, strategyBorscht)
+	choice = dinnerservice.Random{}.Choose(twice, dinnerservice.Profile{}, fixedIntN(0))
+	assert.Equal(t, 2, choice.Weight)
+
+	choice = dinnerservice.Random{}.Choose(nil, dinnerservice.Profile{}, fixedIntN(0))
+	assert.Equal(t, -1, choice.Index)
+}
+
+func TestStrategyRating(t *testing.T) {
+	options := strategyOptions(strategyBorscht, strategyCaesar, strategyShchi)
+	profile := dinnerservice.NewProfile(map[int64]int{1: 1, 2: 5}, nil)
+
+	// Веса: борщ 1, цезарь 5, щи без оценки DefaultRating
+	total := 1 + 5 + dinnerservice.DefaultRating
+	choice := dinnerservice.Rating{}.Choose(options, profile, fixedIntN(0))
+	assert.Equal(t, 0, choice.Index)
+	assert.Equal(t, 1, choice.Weight)
+	assert.Equal(t, total, choice.Total)
+	for roll := 1; roll <= 5; roll++ {
+		choice = dinnerservice.Rating{}.Choose(options, profile, fixedIntN(roll))
+		assert.Equal(t, 1, choice.Index, "roll %d", roll)
+		assert.Equal(t, 5, choice.Weight)
+	}
+	choice = dinnerservice.Rating{}.Choose(options, profile, fixedIntN(total-1))
+	assert.Equal(t, 2, choice.Index)
+
+	// Вес сочетания умножается на оценку
+	options[0].Weight = 0
+	choice = dinnerservice.Rating{}.Choose(options, profile, fixedIntN(0))
+	assert.Equal(t, 1, choice.Index)
+	assert.Equal(t, 5+dinnerservice.DefaultRating, choice.Total)
+}
+
+func TestStrategyFresh(t *testing.T) {
+	day := time.Date(2025, time.January, 10, 19, 0, 0, 0, time.UTC)
+	options := strategyOptions(strategyBorscht, strategyCaesar, strategyShchi)
+
+	history := []models.HistoryEntry{
+		{Time: day, Foods: []models.Food{strategyBorscht}},
+		{Time: day.AddDate(0, 0, 1), Foods: []models.Food{strategyCaesar}},
+		{Time: day.AddDate(0, 0, 2), Foods: []models.Food{strategyShchi}},
+		{Time: day.AddDate(0, 0, 3), Foods: []models.Food{strategyCaesar}},
+	}
+	profile := dinnerservice.NewProfile(nil, history)
+	for roll := range 3 {
+		choice := dinnerservice.Fresh{}.Choose(options, profile, fixedIntN(roll))
+		assert.Equal(t, 0, choice.Index, "borscht was served first")
+	}
+
+	// Ни разу не предлагавшиеся блюда - раньше всех, из них выбор случайный
+	profile = dinnerservice.NewProfile(nil, history[3:])
+	choice := dinnerservice.Fresh{}.Choose(options, profile, fixedIntN(0))
+	assert.Equal(t, 0, choice.Index)
+	assert.Equal(t, 2, choice.Total)
+	choice = dinnerservice.Fresh{}.Choose(options, profile, fixedIntN(1))
+	assert.Equal(t, 2, choice.Index)
+	assert.Equal(t, 2, choice.Total)
+
+	// Варианты с нулевым весом не выбираются
+	profile = dinnerservice.NewProfile(nil, history)
+	options[0].Weight = 0
+	choice = dinnerservice.Fresh{}.Choose(options, profile, fixedIntN(0))
+	assert.Equal(t, 2, choice.Index)
+}
+
+func TestStrategyRoundRobin(t *testing.T) {
+	// Порядок вариантов не важен, очередь идет по id
+	options := strategyOptions(strategyShchi, strategyBorscht, strategyCaesar)
+	next := func(last models.Food) models.Food {
+		profile := dinnerservice.NewProfile(nil, []models.HistoryEntry{{Foods: []models.Food{last}}})
+		choice := dinnerservice.RoundRobin{}.Choose(options, profile, nil)
+		require.GreaterOrEqual(t, choice.Index, 0)
+		return options[choice.Index].Foods[0]
+	}
+	assert.Equal(t, strategyCaesar, next(strategyBorscht))
+	assert.Equal(t, strategyShchi, next(strategyCaesar))
+	// После последнего - снова первое
+	assert.Equal(t, strategyBorscht, next(strategyShchi))
+
+	// Без истории - первое блюдо каталога
+	choice := dinnerservice.RoundRobin{}.Choose(options, dinnerservice.Profile{}, nil)
+	assert.Equal(t, 1, choice.Index)
+
+	// Недоступное блюдо пропускается
+	options[2].Weight = 0
+	assert.Equal(t, strategyShchi, next(strategyBorscht))
+}
+
+func TestGetDinnerMode(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	foods := []models.Food{strategyBorscht, strategyCaesar, strategyShchi}
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	mockProfileProvider := new(MockProfileProvider)
+	mockProfileProvider.On("GetRatings", int64(1)).Return(map[int64]int{}, nil)
+	mockProfileProvider.On("GetHistory", int64(1)).Return([]models.HistoryEntry{
+		{Time: time.Now(), Foods: []models.Food{strategyCaesar}},
+	}, nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, mockProfileProvider, nil)
+
+	dinner, trace, err := dinnerService.GetDinnerTrace(1, dinnerservice.Options{Mode: dinnerservice.StrategyRoundRobin})
+	require.NoError(t, err)
+	assert.Equal(t, []models.Food{strategyShchi}, dinner)
+	assert.Equal(t, dinnerservice.StrategyRoundRobin, trace.Strategy)
+
+	// В случайном режиме профиль не запрашивается
+	_, err = dinnerService.GetDinner(2, dinnerservice.Options{})
+	require.NoError(t, err)
+	mockProfileProvider.AssertNotCalled(t, "GetHistory", int64(2))
+
+	_, err = dinnerService.GetDinner(1, dinnerservice.Options{Mode: "best"})
+	assert.ErrorIs(t, err, services.ErrUnknownMode)
+
+	// Оценки
+	mockProfileProvider.On("SetRating", int64(1), int64(2), 5).Return(nil)
+	require.NoError(t, dinnerService.Rate(1, 2, 5))
+	assert.ErrorIs(t, dinnerService.Rate(1, 2, 6), services.ErrInvalidRating)
+	mockProfileProvider.AssertExpectations(t)
+}
@@ -0,0 +1,48 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	dinnerservice "dinner/internal/services/dinner"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSuggestions(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	foods := []models.Food{strategyBorscht, strategyCaesar, strategyShchi}
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("IsLimit", int64(1)).Return(true, nil)
+	mockHistoryProvider.On("IsLimit", int64(2)).Return(false, nil)
+	mockHistoryProvider.On("SavePlanRequest", int64(1), []models.Food(nil)).Return(nil)
+	mockProfileProvider := new(MockProfileProvider)
+	mockProfileProvider.On("GetRatings", mock.Anything).Return(map[int64]int{}, nil)
+	mockProfileProvider.On("GetHistory", mock.Anything).Return([]models.HistoryEntry{}, nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, mockProfileProvider, nil)
+
+	// Вариантов не больше, чем разных ужинов в каталоге, и они не повторяются
+	for _, mode := range []string{dinnerservice.StrategyRandom, dinnerservice.StrategyRoundRobin} {
+		suggestions, err := dinnerService.GetSuggestions(1, 5, dinnerservice.Options{Mode: mode})
+		require.NoError(t, err, mode)
+		assert.ElementsMatch(t, [][]models.Food{{strategyBorscht}, {strategyCaesar}, {strategyShchi}}, suggestions, mode)
+	}
+
+	suggestions, err := dinnerService.GetSuggestions(1, 2, dinnerservice.Options{Exclude: []string{"Caesar"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, [][]models.Food{{strategyBorscht}, {strategyShchi}}, suggestions)
+
+	_, err = dinnerService.GetSuggestions(2, 5, dinnerservice.Options{})
+	assert.ErrorIs(t, err, services.ErrAttemptLimitExceeded)
+
+	// Каждый подбор вариантов - один запрос в лимите без блюд в истории
+	mockHistoryProvider.AssertNotCalled(t, "SaveRequest", mock.Anything, mock.Anything)
+	mockHistoryProvider.AssertNumberOfCalls(t, "SavePlanRequest", 3)
+}
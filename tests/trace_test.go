@@ -0,0 +1,113 @@
+package dinner
+
+import (
+	"dinner/internal/domain/models"
+	"dinner/internal/services"
+	dinnerservice "dinner/internal/services/dinner"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDinnerTrace(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	foods := []models.Food{
+		{ID: 1, Name: "Okroshka", Category: models.Soup, Seasons: []models.Season{models.Summer}},
+		{ID: 2, Name: "Fish", Category: models.Salad, Weekdays: []time.Weekday{time.Thursday}},
+		{ID: 3, Name: "Mushroom soup", Category: models.Soup, Ingredients: []string{"грибы"}},
+		{ID: 4, Name: "Borscht", Category: models.Soup},
+	}
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, nil, nil, nil)
+
+	// 2025-01-16 - четверг зимой
+	thursday := time.Date(2025, time.January, 16, 19, 0, 0, 0, time.UTC)
+	opts := dinnerservice.Options{Date: thursday, Exclude: []string{"грибы"}}
+	dinner, trace, err := dinnerService.WithSeed(7).GetDinnerTrace(1, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(7), trace.Seed)
+	assert.Equal(t, []models.TraceStep{
+		{Name: models.TraceCatalog, Pool: 4},
+		{Name: models.TraceRestrictions, Pool: 3},
+		{Name: models.TraceSeason, Pool: 2},
+		// Рыба в четверг считается WeekdayBoost раз
+		{Name: models.TraceWeekday, Pool: 1 + dinnerservice.WeekdayBoost},
+	}, trace.Steps)
+	require.Len(t, trace.Draws, 1)
+	draw := trace.Draws[0]
+	assert.Equal(t, models.DrawDish, draw.Name)
+	assert.Equal(t, dinner, draw.Chosen)
+	assert.Equal(t, 1+dinnerservice.WeekdayBoost, draw.Total)
+	assert.Less(t, draw.Roll, draw.Total)
+	if dinner[0].Name == "Fish" {
+		assert.Equal(t, dinnerservice.WeekdayBoost, draw.Weight)
+	} else {
+		assert.Equal(t, 1, draw.Weight)
+	}
+
+	// Тот же seed - то же объяснение
+	_, again, err := dinnerService.WithSeed(7).GetDinnerTrace(1, opts)
+	require.NoError(t, err)
+	assert.Equal(t, trace, again)
+}
+
+func TestGetDinnerTraceKcal(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	foods := []models.Food{
+		{ID: 1, Name: "Котлеты", Category: models.Meat, Nutrition: &models.Nutrition{Kcal: 400}},
+		{ID: 2, Name: "Рис", Category: models.SideDish, Nutrition: &models.Nutrition{Kcal: 200}},
+		{ID: 3, Name: "Пюре", Category: models.SideDish, Nutrition: &models.Nutrition{Kcal: 300}},
+		{ID: 4, Name: "Салат", Category: models.Salad, Nutrition: &models.Nutrition{Kcal: 150}},
+	}
+	mockFoodProvider := new(MockFoodProvider)
+	mockFoodProvider.On("GetFoods").Return(foods, nil)
+	mockHistoryProvider := new(MockHistoryProvider)
+	mockHistoryProvider.On("SaveRequest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryProvider.On("IsLimit", mock.Anything).Return(true, nil)
+	pairings := new(MockPairingProvider)
+	pairings.On("GetPairings").Return([]models.Pairing{{MeatID: 1, SideID: 2, Weight: models.PairPreferred}}, nil)
+	dinnerService := dinnerservice.New(log, mockFoodProvider, mockHistoryProvider, pairings, nil, dinnerservice.NewSource(3))
+
+	dinner, trace, err := dinnerService.GetDinnerTrace(1, dinnerservice.Options{Kcal: models.KcalRange{Min: 500, Max: 650}})
+	require.NoError(t, err)
+	step, ok := trace.Step(models.TraceDinners)
+	require.True(t, ok)
+	assert.Equal(t, 3, step.Pool)
+	step, ok = trace.Step(models.TraceKcal)
+	require.True(t, ok)
+	assert.Equal(t, 1, step.Pool, "only cutlets with rice fit")
+	assert.Equal(t, []models.Food{foods[0], foods[1]}, dinner)
+	// Первое блюдо - котлеты или рис, как без цели по калориям, затем пара к нему
+	require.Len(t, trace.Draws, 2)
+	assert.Equal(t, models.DrawDish, trace.Draws[0].Name)
+	assert.Equal(t, 2, trace.Draws[0].Options)
+	pair := foods[0]
+	if trace.Draws[0].Chosen[0].ID == pair.ID {
+		pair = foods[1]
+	}
+	assert.Equal(t, models.TraceDraw{
+		Name:    models.DrawPair,
+		Chosen:  []models.Food{pair},
+		Weight:  models.PairPreferred,
+		Total:   models.PairPreferred,
+		Options: 1,
+		Roll:    trace.Draws[1].Roll,
+	}, trace.Draws[1])
+
+	// Если ничего не подошло, ошибка и пустое объяснение
+	_, trace, err = dinnerService.GetDinnerTrace(1, dinnerservice.Options{Kcal: models.KcalRange{Min: 2000}})
+	assert.ErrorIs(t, err, services.ErrNoMatchingDinner)
+	assert.Empty(t, trace.Steps)
+}